@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"tui-portfolio/cmd/server"
+)
+
+// runExport renders the portfolio data to a static HTML page, plain text
+// résumé or Markdown document and returns the exit code
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Usage: %s export [options]
+
+Renders the portfolio data to a standalone HTML page, a plain text résumé, a
+Markdown document, JSON or a vCard, so the same data file can drive a website.
+
+Options:
+`, os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	var (
+		dataPath = fs.String("data", defaultDataPath, "Path to portfolio data file (JSON, YAML or TOML) or Markdown directory")
+		format   = fs.String("format", "", "Data file format: json, yaml, toml or markdown (default: detected from path)")
+		to       = fs.String("to", "", "Export format: html, text, markdown, json or vcard (default: detected from -o, text for stdout)")
+		output   = fs.String("o", "", "Output file (default: stdout)")
+		theme    = fs.String("theme", "", "Color theme of HTML pages (default: the data file's, else "+server.DefaultThemeName+")")
+		themes   = fs.String("themes", "", "Directory of theme files (JSON, TOML or YAML) to add to the built-in themes")
+	)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	dataFormat, err := server.ParseDataFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid -format:", err)
+		return 2
+	}
+
+	exportFormat, err := server.ParseExportFormat(*to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid -to:", err)
+		return 2
+	}
+	if exportFormat == server.ExportAuto {
+		exportFormat = server.DetectExportFormat(*output)
+	}
+
+	dataLoader := server.NewDataLoader(*dataPath, dataFormat)
+	if err := dataLoader.LoadData(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *dataPath, err)
+		return 1
+	}
+	if err := dataLoader.ValidateData(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *dataPath, err)
+		return 1
+	}
+
+	userThemes, err := server.LoadThemes(*themes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	pageTheme, err := server.ExportTheme(*theme, server.AvailableThemes(userThemes), dataLoader.Snapshot())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid -theme:", err)
+		return 2
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to create output file:", err)
+			return 1
+		}
+		defer out.Close()
+	}
+
+	w := bufio.NewWriter(out)
+	if err := server.Export(w, dataLoader.Snapshot(), exportFormat, pageTheme); err != nil {
+		fmt.Fprintln(os.Stderr, "Export failed:", err)
+		return 1
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to write output:", err)
+		return 1
+	}
+
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "Exported %s to %s\n", exportFormat, *output)
+	}
+	return 0
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
-	"log"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 	"tui-portfolio/cmd/server"
+
+	"github.com/charmbracelet/log"
 )
 
 const (
@@ -16,34 +20,212 @@ const (
 	defaultPort       = 2222
 	defaultSSHKeyPath = ".ssh/term_info_ed25519"
 	defaultDataPath   = "data/portfolio.json"
+	defaultWatch      = 2 * time.Second
+
+	defaultAnalyticsPath = "analytics.jsonl"
+
+	// Session limits for a publicly exposed server
+	defaultMaxSessions = 100
+	defaultRateLimit   = 10
+	defaultMaxDuration = 30 * time.Minute
+	defaultIdleTimeout = 10 * time.Minute
+
+	defaultLogLevel  = "info"
+	defaultLogFormat = "text"
+
+	// configEnv names the config file when -config isn't set
+	configEnv = "PORTFOLIO_CONFIG"
 )
 
-func main() {
-	// Command line flags
+// defaultConfig returns the settings used when neither the config file, the
+// environment nor a flag sets them
+func defaultConfig() server.Config {
+	return server.Config{
+		Host:     defaultHost,
+		Port:     defaultPort,
+		HostKeys: []string{defaultSSHKeyPath},
+		Data:     defaultDataPath,
+		Watch:    server.Duration(defaultWatch),
+		Sections: server.NavigationSectionNames(),
+		Effects:  true,
+		Limits: server.LimitsConfig{
+			MaxSessions: defaultMaxSessions,
+			RateLimit:   defaultRateLimit,
+			MaxDuration: server.Duration(defaultMaxDuration),
+			IdleTimeout: server.Duration(defaultIdleTimeout),
+		},
+		Log: server.LogConfig{
+			Level:  defaultLogLevel,
+			Format: defaultLogFormat,
+		},
+	}
+}
+
+// options are the command line settings that aren't server settings
+type options struct {
+	configPath string
+	local      bool
+	help       bool
+}
+
+// loadConfig parses the command line flags in args with fs and merges the
+// settings from the defaults, the config file, the environment (read through
+// lookupEnv) and the flags that are set, each overriding the one before.
+// Flags that aren't set keep the values from the others.
+func loadConfig(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (server.Config, options, error) {
 	var (
-		host     = flag.String("host", defaultHost, "Host to bind the SSH server to")
-		port     = flag.Uint("port", defaultPort, "Port to bind the SSH server to")
-		dataPath = flag.String("data", defaultDataPath, "Path to portfolio data JSON file")
-		help     = flag.Bool("help", false, "Show help message")
+		configPath  = fs.String("config", "", "Config file (TOML, YAML or JSON) to read settings from (default: $"+configEnv+")")
+		host        = fs.String("host", defaultHost, "Host to bind the SSH server to")
+		port        = fs.Uint("port", defaultPort, "Port to bind the SSH server to")
+		hostKeys    = fs.String("host-keys", defaultSSHKeyPath, "Comma separated SSH host key paths, generated if missing")
+		dataPath    = fs.String("data", defaultDataPath, "Path to portfolio data file (JSON, YAML or TOML) or Markdown directory")
+		format      = fs.String("format", "", "Data file format: json, yaml, toml or markdown (default: detected from path)")
+		watch       = fs.Duration("watch", defaultWatch, "Interval for checking the data file for changes (0 disables)")
+		httpAddr    = fs.String("http", "", "Address to serve the browser terminal and text pages on, e.g. :8080 (disabled by default)")
+		metricsAddr = fs.String("metrics", "", "Address to serve Prometheus metrics on at /metrics, e.g. localhost:9090 (disabled by default)")
+		analytics   = fs.String("analytics", "", "File to record visitor analytics to as JSON Lines, e.g. "+defaultAnalyticsPath+" (disabled by default)")
+		sections    = fs.String("sections", strings.Join(server.NavigationSectionNames(), ","), "Comma separated sections to show, in tab order")
+		effects     = fs.Bool("effects", true, "Start sessions with particle effects enabled")
+		theme       = fs.String("theme", "", "Color theme sessions start in (default: the data file's, else "+server.DefaultThemeName+")")
+		themes      = fs.String("themes", "", "Directory of theme files (JSON, TOML or YAML) to add to the built-in themes")
+		accessible  = fs.Bool("accessible", false, "Start sessions in accessible mode: no emoji or motion, high contrast, plain layout")
+		maxSessions = fs.Int("max-sessions", defaultMaxSessions, "Maximum number of concurrent sessions (0 for no limit)")
+		rateLimit   = fs.Int("rate-limit", defaultRateLimit, "New sessions allowed per client address per minute (0 for no limit)")
+		maxDuration = fs.Duration("max-duration", defaultMaxDuration, "Maximum length of a session (0 for no limit)")
+		idleTimeout = fs.Duration("idle-timeout", defaultIdleTimeout, "Disconnect sessions after this long without input (0 disables)")
+		logLevel    = fs.String("log-level", defaultLogLevel, "Minimum level to log: debug, info, warn or error")
+		logFormat   = fs.String("log-format", defaultLogFormat, "Log output format: text or json")
+		local       = fs.Bool("local", false, "Run the portfolio in this terminal instead of starting the SSH server")
+		help        = fs.Bool("help", false, "Show help message")
 	)
-	flag.Parse()
+	if err := fs.Parse(args); err != nil {
+		return server.Config{}, options{}, err
+	}
+	opts := options{configPath: *configPath, local: *local, help: *help}
+	cfg := defaultConfig()
+	if opts.help {
+		return cfg, opts, nil
+	}
+
+	if opts.configPath == "" {
+		opts.configPath, _ = lookupEnv(configEnv)
+	}
+	if opts.configPath != "" {
+		if err := cfg.LoadConfigFile(opts.configPath); err != nil {
+			return server.Config{}, options{}, err
+		}
+	}
+	if err := cfg.ApplyEnv(lookupEnv); err != nil {
+		return server.Config{}, options{}, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			cfg.Host = *host
+		case "port":
+			cfg.Port = *port
+		case "host-keys":
+			cfg.HostKeys = server.SplitList(*hostKeys)
+		case "data":
+			cfg.Data = *dataPath
+		case "format":
+			cfg.DataFormat = *format
+		case "watch":
+			cfg.Watch = server.Duration(*watch)
+		case "http":
+			cfg.HTTP = *httpAddr
+		case "metrics":
+			cfg.Metrics = *metricsAddr
+		case "analytics":
+			cfg.Analytics = *analytics
+		case "sections":
+			cfg.Sections = server.SplitList(*sections)
+		case "effects":
+			cfg.Effects = *effects
+		case "theme":
+			cfg.Theme = *theme
+		case "themes":
+			cfg.Themes = *themes
+		case "accessible":
+			cfg.Accessible = *accessible
+		case "max-sessions":
+			cfg.Limits.MaxSessions = *maxSessions
+		case "rate-limit":
+			cfg.Limits.RateLimit = *rateLimit
+		case "max-duration":
+			cfg.Limits.MaxDuration = server.Duration(*maxDuration)
+		case "idle-timeout":
+			cfg.Limits.IdleTimeout = server.Duration(*idleTimeout)
+		case "log-level":
+			cfg.Log.Level = *logLevel
+		case "log-format":
+			cfg.Log.Format = *logFormat
+		}
+	})
+	return cfg, opts, nil
+}
+
+func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(runValidate(os.Args[2:]))
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "stats":
+			os.Exit(runStats(os.Args[2:]))
+		}
+	}
 
-	if *help {
+	cfg, opts, err := loadConfig(flag.CommandLine, os.Args[1:], os.LookupEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if opts.help {
 		printHelp()
 		return
 	}
 
+	// Theme files are read once; the server gets the themes loaded here
+	userThemes, err := server.LoadThemes(cfg.Themes)
+	if err != nil {
+		err = fmt.Errorf("themes: %w", err)
+	}
+	if err := errors.Join(err, cfg.Validate(userThemes)); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+
+	logger, err := server.NewLogger(os.Stderr, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	log.SetDefault(logger)
+	if opts.configPath != "" {
+		log.Info("Loaded configuration", "path", opts.configPath)
+	}
+
 	// Validate data file exists
-	if _, err := os.Stat(*dataPath); os.IsNotExist(err) {
-		log.Printf("Warning: Data file not found at %s", *dataPath)
-		log.Printf("The application will start with fallback content.")
-		log.Printf("Create the data file or use -data flag to specify a different path.")
+	if _, err := os.Stat(cfg.Data); os.IsNotExist(err) {
+		log.Warn("Data file not found, the application will start with fallback content", "path", cfg.Data)
+		log.Warn("Create the data file or use -data flag to specify a different path")
+	}
+
+	// Preview in the current terminal
+	if opts.local {
+		if err := server.RunLocal(cfg, userThemes); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
 	// Create and start server
-	srv, err := server.NewServer(*host, *port, defaultSSHKeyPath, *dataPath)
+	srv, err := server.NewServer(cfg, userThemes)
 	if err != nil {
-		log.Fatalln("Failed to create server:", err)
+		log.Fatal("Failed to create server", "err", err)
 	}
 
 	// Setup graceful shutdown
@@ -53,18 +235,17 @@ func main() {
 	// Start server in goroutine
 	go func() {
 		if err = srv.ListenAndServe(); err != nil {
-			log.Fatalln("Server failed:", err)
+			log.Fatal("Server failed", "err", err)
 		}
 	}()
 
-	log.Printf("Portfolio SSH server running on %s:%d", *host, *port)
-	log.Printf("Data source: %s", *dataPath)
-	log.Printf("Press Ctrl+C to stop")
+	log.Info("Portfolio SSH server running", "addr", fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), "data", cfg.Data)
+	log.Info("Press Ctrl+C to stop")
 
 	// Wait for shutdown signal
 	<-done
 
-	log.Println("Shutting down SSH server...")
+	log.Info("Shutting down SSH server...")
 
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -72,24 +253,78 @@ func main() {
 
 	// Shutdown server gracefully
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Printf("Server shutdown error: %v", err)
+		log.Error("Server shutdown failed", "err", err)
 	} else {
-		log.Println("Server shutdown complete")
+		log.Info("Server shutdown complete")
 	}
 }
 
 func printHelp() {
-	log.Printf(`Portfolio SSH Terminal Server
+	fmt.Fprintf(os.Stderr, `Portfolio SSH Terminal Server
 
 Usage: %s [options]
+       %s validate [-data path] [-format name] [-themes dir] [-strict] [path ...]
+       %s export [-data path] [-format name] [-to html|text|markdown|json|vcard] [-o file] [-theme name]
+       %s stats [-days N] [file]
+
+Commands:
+  validate
+        Check data files without starting the server. Prints every problem
+        with its location and exits non-zero on errors (see validate -help)
+  export
+        Render the portfolio to a standalone HTML page, a plain text résumé, a
+        Markdown document, JSON or a vCard (see export -help)
+  stats
+        Summarize a log recorded with -analytics: visitors per day, average
+        session length and the most viewed sections (see stats -help)
 
 Options:
+  -config string
+        Config file (TOML, YAML or JSON) to read settings from (default: $%s)
   -host string
         Host to bind the SSH server to (default "%s")
   -port uint
         Port to bind the SSH server to (default %d)
+  -host-keys string
+        Comma separated SSH host key paths, generated if missing (default "%s")
   -data string
-        Path to portfolio data JSON file (default "%s")
+        Path to portfolio data file: JSON, YAML, TOML or a Markdown directory (default "%s")
+  -format string
+        Data file format: json, yaml, toml or markdown (default: detected from path)
+  -watch duration
+        Interval for checking the data file for changes, 0 disables (default %s)
+  -http string
+        Address to serve the browser terminal and text pages on, e.g. :8080 (disabled by default)
+  -metrics string
+        Address to serve Prometheus metrics on at /metrics, e.g. localhost:9090 (disabled by default)
+  -analytics string
+        File to record visitor analytics to as JSON Lines, e.g. %s (disabled by default)
+  -sections string
+        Comma separated sections to show, in tab order (default "%s")
+  -effects
+        Start sessions with particle effects enabled (default true)
+  -theme string
+        Color theme sessions start in: %s
+        (default: the data file's theme, else %s)
+  -themes string
+        Directory of theme files (JSON, TOML or YAML) to add to the built-in themes
+  -accessible
+        Start sessions in accessible mode: no emoji or motion, high contrast, plain
+        layout. Visitors can choose with ssh -o SetEnv=PORTFOLIO_A11Y=1 (or =0)
+  -max-sessions int
+        Maximum number of concurrent sessions, 0 for no limit (default %d)
+  -rate-limit int
+        New sessions allowed per client address per minute, 0 for no limit (default %d)
+  -max-duration duration
+        Maximum length of a session, 0 for no limit (default %s)
+  -idle-timeout duration
+        Disconnect sessions after this long without input, 0 disables (default %s)
+  -log-level string
+        Minimum level to log: debug, info, warn or error (default "info")
+  -log-format string
+        Log output format: text or json (default "text")
+  -local
+        Run the portfolio in this terminal instead of starting the SSH server
   -help
         Show this help message
 
@@ -97,31 +332,98 @@ Examples:
   # Start with default settings
   %s
 
+  # Preview the portfolio in this terminal, no SSH client needed
+  %s -local
+
   # Start on different port with custom data file
   %s -port 3333 -data ./my-portfolio.json
 
+  # Load portfolio data written in YAML
+  %s -data ./my-portfolio.yaml
+
+  # Load portfolio content from a directory of Markdown files
+  %s -data ./data/portfolio
+
+  # Lint data files, e.g. from a pre-commit hook
+  %s validate -strict data/portfolio.json
+
+  # Export the portfolio as a web page
+  %s export -o portfolio.html
+
+  # Also serve the portfolio to browsers at http://localhost:8080
+  %s -http :8080
+
+  # Record which sections visitors read, then summarize the last week
+  %s -analytics %s
+  %s stats -days 7 %s
+
+  # Read settings from a config file, overriding its port
+  %s -config portfolio.toml -port 3333
+
   # Start on all interfaces
   %s -host 0.0.0.0
 
 Data File:
-  The data file should be a JSON file containing your portfolio information.
-  See the included portfolio.json for the expected structure.
+  The data file can be JSON (.json), YAML (.yaml/.yml) or TOML (.toml) and
+  uses the same field names in every format. See the included portfolio.json
+  for the expected structure and portfolio.schema.json for the JSON Schema.
+  Every validation problem is reported with its location; errors stop the
+  server from starting, warnings are only logged.
+
+  The data path can also be a directory of Markdown files with YAML front
+  matter (about.md, contact.md, skills.md, facts.md, experience/*.md,
+  projects/*.md). See data/portfolio for an example.
+  Changes to the file are picked up automatically and pushed to every
+  connected session; invalid edits are logged and the last good data is kept.
+
+Configuration:
+  Settings can also come from a TOML, YAML or JSON config file (-config or
+  $%s) and from PORTFOLIO_* environment variables, e.g. PORTFOLIO_PORT=3333
+  or PORTFOLIO_SECTIONS=about,skills,contact. Flags override environment
+  variables, which override the config file, which overrides the defaults.
+  See config.example.toml for every setting. The merged settings are
+  validated on startup and every problem is reported.
 
 Connection:
   Once running, connect with: ssh %s -p %d
+  Add a command to print a single section and exit, e.g. ssh %s -p %d skills.
+  Commands: about, experience, skills, projects, contact, json, vcard, help.
+  With -http set, the portfolio can also be opened in a web browser, and
+  curl http://host:port/ prints every section as colored text. Single sections
+  are at /about, /experience, /skills, /projects and /contact; add ?color=0 to
+  disable colors, ?width=N to change the wrapping width or ?a11y=1 for
+  accessible mode.
 
 Controls (once connected):
   Tab/Shift+Tab  Navigate sections
   ?              Toggle help
+  t              Switch color theme
+  b              Switch between light and dark
+  a              Toggle accessible mode
   e              Toggle effects
   x              Trigger explosion
   q              Quit
 `,
+		os.Args[0], os.Args[0], os.Args[0], os.Args[0],
+		configEnv,
+		defaultHost, defaultPort, defaultSSHKeyPath, defaultDataPath, defaultWatch,
+		defaultAnalyticsPath, strings.Join(server.NavigationSectionNames(), ","),
+		strings.Join(server.ThemeNames(), ", "), server.DefaultThemeName,
+		defaultMaxSessions, defaultRateLimit, defaultMaxDuration, defaultIdleTimeout,
 		os.Args[0],
-		defaultHost, defaultPort, defaultDataPath,
 		os.Args[0],
 		os.Args[0],
 		os.Args[0],
+		os.Args[0],
+		os.Args[0],
+		os.Args[0],
+		os.Args[0],
+		os.Args[0], defaultAnalyticsPath,
+		os.Args[0], defaultAnalyticsPath,
+		os.Args[0],
+		os.Args[0],
+		configEnv,
+		defaultHost, defaultPort,
 		defaultHost, defaultPort,
 	)
 }
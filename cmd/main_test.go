@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"tui-portfolio/cmd/server"
+)
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "portfolio.toml")
+	file := `
+host = "file-host"
+port = 3000
+data = "file.json"
+theme = "nord"
+watch = "1m"
+
+[limits]
+max_sessions = 5
+rate_limit = 7
+`
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		configEnv:                path,
+		"PORTFOLIO_PORT":         "4000",
+		"PORTFOLIO_THEME":        "dracula",
+		"PORTFOLIO_MAX_SESSIONS": "6",
+		"PORTFOLIO_SECTIONS":     "about, contact",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg, opts, err := loadConfig(fs, []string{"-theme", "gruvbox", "-rate-limit", "8", "-local"}, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.configPath != path || !opts.local {
+		t.Errorf("options = %+v", opts)
+	}
+
+	checks := []struct {
+		name      string
+		got, want any
+	}{
+		{"default", cfg.HostKeys, []string{defaultSSHKeyPath}},
+		{"default", cfg.Effects, true},
+		{"file", cfg.Host, "file-host"},
+		{"file", cfg.Data, "file.json"},
+		{"file", cfg.Watch, server.Duration(time.Minute)},
+		{"env over file", cfg.Port, uint(4000)},
+		{"env over file", cfg.Limits.MaxSessions, 6},
+		{"env over default", cfg.Sections, []string{"about", "contact"}},
+		{"flag over env", cfg.Theme, "gruvbox"},
+		{"flag over file", cfg.Limits.RateLimit, 8},
+		{"default kept", cfg.Limits.MaxDuration, server.Duration(defaultMaxDuration)},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.got, check.want) {
+			t.Errorf("%s: got %v, want %v", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestLoadConfigFlagDefaultsDontOverride(t *testing.T) {
+	// A flag left unset doesn't put its default back over the environment
+	lookup := func(name string) (string, bool) {
+		if name == "PORTFOLIO_HOST" {
+			return "env-host", true
+		}
+		return "", false
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg, _, err := loadConfig(fs, []string{"-port", "2200"}, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "env-host" || cfg.Port != 2200 {
+		t.Errorf("host %q port %d, want env-host and 2200", cfg.Host, cfg.Port)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknown, []byte("hots: typo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+	}{
+		{"unknown flag", []string{"-nope"}, nil},
+		{"missing config file", []string{"-config", filepath.Join(dir, "missing.toml")}, nil},
+		{"unknown setting", []string{"-config", unknown}, nil},
+		{"bad environment", nil, map[string]string{"PORTFOLIO_PORT": "twenty"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(name string) (string, bool) {
+				value, ok := tt.env[name]
+				return value, ok
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			if _, _, err := loadConfig(fs, tt.args, lookup); err == nil {
+				t.Error("no error")
+			}
+		})
+	}
+}
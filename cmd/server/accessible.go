@@ -0,0 +1,191 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// accessibleEnv is the environment variable visitors set to ask for
+// accessible mode, e.g. ssh -o SetEnv=PORTFOLIO_A11Y=1
+const accessibleEnv = "PORTFOLIO_A11Y"
+
+// envAccessible reports whether an environment asks for accessible mode,
+// falling back to def if it doesn't say either way
+func envAccessible(environ []string, def bool) bool {
+	for _, v := range environ {
+		if value, ok := strings.CutPrefix(v, accessibleEnv+"="); ok {
+			if on, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+				return on
+			}
+		}
+	}
+	return def
+}
+
+// setAccessible turns accessible mode on or off. It switches to the high
+// contrast theme and back to the theme used before, unless the visitor has
+// picked another since.
+func (m *PortfolioModel) setAccessible(on bool) {
+	if on == m.accessible {
+		return
+	}
+	m.accessible = on
+	m.particles = m.particles[:0]
+
+	if on {
+		m.themeBeforeAccessible = m.theme
+		if highContrast, ok := findTheme(m.themes, HighContrastThemeName); ok {
+			m.setTheme(highContrast.variantFor(m.themes, m.theme.Dark))
+			return
+		}
+	} else if m.themeBeforeAccessible != nil && m.isHighContrast() {
+		m.setTheme(m.themeBeforeAccessible.variantFor(m.themes, m.theme.Dark))
+		return
+	}
+	m.setTheme(m.theme)
+}
+
+// isHighContrast reports whether the session uses the high contrast theme or
+// its variant
+func (m *PortfolioModel) isHighContrast() bool {
+	return m.theme.Name == HighContrastThemeName || m.theme.Variant == HighContrastThemeName
+}
+
+// animated reports whether anything on screen may move
+func (m *PortfolioModel) animated() bool {
+	return m.effectsEnabled && !m.accessible
+}
+
+// linearStyles removes the borders, backgrounds and alignment from every
+// style, so screen readers and braille displays get the text alone, in
+// reading order
+func linearStyles(styles *PortfolioStyles) {
+	fields := reflect.ValueOf(styles).Elem()
+	for i := range fields.NumField() {
+		style := fields.Field(i).Interface().(lipgloss.Style)
+		style = style.
+			UnsetBorderStyle().
+			UnsetBorderTop().
+			UnsetBorderRight().
+			UnsetBorderBottom().
+			UnsetBorderLeft().
+			UnsetBackground().
+			UnsetAlign()
+		fields.Field(i).Set(reflect.ValueOf(style))
+	}
+}
+
+// label shows a value after its icon, or after a text label in accessible
+// mode, where the icon would be read out as its name
+func (m *PortfolioModel) label(icon, name, value string) string {
+	if m.accessible {
+		return name + ": " + value + "."
+	}
+	return icon + " " + value
+}
+
+// joinMeta joins labels into a line of details
+func (m *PortfolioModel) joinMeta(labels []string) string {
+	if m.accessible {
+		return strings.Join(labels, " ")
+	}
+	return strings.Join(labels, " • ")
+}
+
+// renderAccessibleTabs names the current section in a sentence instead of
+// drawing tabs
+func (m *PortfolioModel) renderAccessibleTabs() string {
+	if m.currentSection == HelpSection {
+		return ansi.Wordwrap("Help. Press ? to go back to the sections.", m.width, "")
+	}
+
+	names := make([]string, len(m.sections))
+	current := 0
+	for i, section := range m.sections {
+		names[i] = sectionTitles[section]
+		if section == m.currentSection {
+			current = i
+		}
+	}
+	tabs := fmt.Sprintf("%s, section %d of %d: %s.",
+		names[current], current+1, len(names), strings.Join(names, ", "))
+	return ansi.Wordwrap(tabs, m.width, "")
+}
+
+// renderAccessibleFooter lists the keys as a sentence. Without the content
+// box's border there's room for it to wrap.
+func (m *PortfolioModel) renderAccessibleFooter() string {
+	keys := "Keys: Tab next, Shift+Tab previous, arrows scroll, ? help, a accessible mode off, q quit."
+	return ansi.Wordwrap(keys, m.width, "")
+}
+
+// linearText prepares rendered text for screen readers and braille displays:
+// emoji are removed, and lines left blank by padding are emptied and merged
+// so there's nothing to step through between paragraphs
+func linearText(s string) string {
+	lines := strings.Split(stripEmoji(s), "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if strings.TrimSpace(ansi.Strip(line)) == "" {
+			if len(out) > 0 && out[len(out)-1] == "" {
+				continue
+			}
+			line = ""
+		}
+		out = append(out, strings.TrimRight(line, " "))
+	}
+	return strings.Join(out, "\n")
+}
+
+// stripEmoji removes emoji and other pictographs from s, along with the space
+// after each, so text reads the same without them. Symbols with meaning in
+// the content, like arrows and bullets, are kept.
+func stripEmoji(s string) string {
+	var out strings.Builder
+	out.Grow(len(s))
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isEmoji(r) {
+			out.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+
+		// Skip the whole emoji: modifiers, joined emoji and one space after
+		i += size
+		for i < len(s) {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r != '\u200d' && r != '\ufe0f' && !isEmoji(r) {
+				break
+			}
+			i += size
+		}
+		if i < len(s) && s[i] == ' ' {
+			i++
+		}
+	}
+	return out.String()
+}
+
+// isEmoji reports whether r is an emoji or pictograph
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1f000 && r <= 0x1faff: // Emoji, pictographs, skin tones
+		return true
+	case r >= 0x2600 && r <= 0x27bf: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x23e9 && r <= 0x23fa: // Clocks and media controls
+		return true
+	case r == 0x2b50 || r == 0x2b55: // Star and circle
+		return true
+	default:
+		return false
+	}
+}
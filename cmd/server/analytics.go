@@ -0,0 +1,248 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Analytics event types
+const (
+	eventSessionStart = "session_start"
+	eventSectionEnter = "section_enter"
+	eventSectionLeave = "section_leave"
+	eventSessionEnd   = "session_end"
+)
+
+// Reasons a session ended
+const (
+	endQuit        = "quit"
+	endDisconnect  = "disconnect"
+	endIdle        = "idle"
+	endMaxDuration = "max_duration"
+	endShutdown    = "shutdown"
+)
+
+// AnalyticsEvent is a single line of the analytics log
+type AnalyticsEvent struct {
+	Time       time.Time `json:"time"`
+	Event      string    `json:"event"`
+	Session    string    `json:"session"`
+	Visitor    string    `json:"visitor,omitempty"`   // Hashed remote address, only on session_start
+	Transport  string    `json:"transport,omitempty"` // ssh or web
+	Term       string    `json:"term,omitempty"`
+	Width      int       `json:"width,omitempty"`
+	Height     int       `json:"height,omitempty"`
+	Section    string    `json:"section,omitempty"`
+	DurationMS int64     `json:"duration_ms,omitempty"`  // Time spent, on section_leave and session_end
+	Scroll     int       `json:"scroll_depth,omitempty"` // Percentage of the section seen, on section_leave
+	Reason     string    `json:"reason,omitempty"`       // Why the session ended, on session_end
+}
+
+// Analytics records what visitors do in interactive sessions to a JSON Lines
+// file. Remote addresses are never written: visitors are identified by a
+// salted hash, and the salt only lives in memory and changes every day, so
+// a visitor can be counted within a day but not followed across days. A nil
+// *Analytics records nothing.
+type Analytics struct {
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	closed bool
+
+	salt    []byte
+	saltDay string
+}
+
+// OpenAnalytics opens the analytics log for appending, creating it if needed
+func OpenAnalytics(path string) (*Analytics, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open analytics log: %w", err)
+	}
+	return &Analytics{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Close stops recording and closes the log. Events from sessions still
+// running are dropped.
+func (a *Analytics) Close() error {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	return a.file.Close()
+}
+
+// StartSession records a new interactive session with the given id and
+// returns the tracker for its navigation
+func (a *Analytics) StartSession(id, transport, remoteIP, term string, width, height int) *AnalyticsSession {
+	if a == nil {
+		return nil
+	}
+
+	s := &AnalyticsSession{
+		analytics: a,
+		id:        id,
+		start:     time.Now(),
+	}
+	a.record(AnalyticsEvent{
+		Event:     eventSessionStart,
+		Session:   s.id,
+		Visitor:   a.visitorID(remoteIP),
+		Transport: transport,
+		Term:      term,
+		Width:     width,
+		Height:    height,
+	})
+	return s
+}
+
+// record appends an event to the log
+func (a *Analytics) record(event AnalyticsEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return
+	}
+	if err := a.enc.Encode(event); err != nil {
+		log.Error("Failed to write analytics event", "err", err)
+	}
+}
+
+// visitorID hashes a remote address with the salt for the current day
+func (a *Analytics) visitorID(remoteIP string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if day := time.Now().UTC().Format(time.DateOnly); day != a.saltDay {
+		a.salt = make([]byte, 32)
+		_, _ = rand.Read(a.salt)
+		a.saltDay = day
+	}
+
+	sum := sha256.Sum256(append(a.salt, remoteIP...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// AnalyticsSession tracks a single session's trail through the sections. A
+// nil *AnalyticsSession records nothing.
+type AnalyticsSession struct {
+	analytics *Analytics
+	id        string
+	start     time.Time
+
+	mu           sync.Mutex
+	section      string
+	sectionStart time.Time
+	scroll       float64
+	reason       string
+	ended        bool
+}
+
+// EnterSection records leaving the current section, if any, and entering a
+// new one
+func (s *AnalyticsSession) EnterSection(section Section) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended || s.section == section.String() {
+		return
+	}
+
+	s.leaveSection()
+	s.section = section.String()
+	s.sectionStart = time.Now()
+	s.scroll = 0
+	s.analytics.record(AnalyticsEvent{Event: eventSectionEnter, Session: s.id, Section: s.section})
+}
+
+// Scrolled records how much of the section has been on screen, as a fraction
+// from 0 to 1. Only the furthest point in each section is kept.
+func (s *AnalyticsSession) Scrolled(depth float64) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scroll = max(s.scroll, depth)
+}
+
+// SetEndReason records why the session is about to end. The first reason
+// set wins; sessions that end without one were disconnected.
+func (s *AnalyticsSession) SetEndReason(reason string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reason == "" {
+		s.reason = reason
+	}
+}
+
+// End records the end of the session. Later calls do nothing.
+func (s *AnalyticsSession) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+
+	s.leaveSection()
+	reason := s.reason
+	if reason == "" {
+		reason = endDisconnect
+	}
+	s.analytics.record(AnalyticsEvent{
+		Event:      eventSessionEnd,
+		Session:    s.id,
+		DurationMS: time.Since(s.start).Milliseconds(),
+		Reason:     reason,
+	})
+}
+
+// leaveSection records leaving the current section. The caller holds s.mu.
+func (s *AnalyticsSession) leaveSection() {
+	if s.section == "" {
+		return
+	}
+	s.analytics.record(AnalyticsEvent{
+		Event:      eventSectionLeave,
+		Session:    s.id,
+		Section:    s.section,
+		DurationMS: time.Since(s.sectionStart).Milliseconds(),
+		Scroll:     int(s.scroll*100 + 0.5),
+	})
+	s.section = ""
+}
+
+// randomID returns a random identifier for a session
+func randomID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
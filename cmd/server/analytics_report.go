@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// AnalyticsSummary aggregates an analytics log
+type AnalyticsSummary struct {
+	Sessions      int
+	Visitors      int // Distinct visitors per day, summed over days
+	TotalDuration time.Duration
+	EndedSessions int // Sessions with a session_end event
+	First, Last   time.Time
+	Days          []DaySummary
+	Sections      []SectionSummary // Most viewed first
+	EndReasons    map[string]int
+	SkippedLines  int // Lines that weren't valid events
+}
+
+// DaySummary counts the sessions started on a single UTC day
+type DaySummary struct {
+	Day      string // YYYY-MM-DD
+	Visitors int
+	Sessions int
+}
+
+// SectionSummary aggregates the visits to a single section. Views counts
+// visits that were left, so sections open when the log was read don't count.
+type SectionSummary struct {
+	Section     string
+	Views       int
+	TotalTime   time.Duration
+	TotalScroll int // Sum of scroll depths in percent, over Views
+}
+
+// AverageDuration returns the average length of the sessions that ended
+func (s *AnalyticsSummary) AverageDuration() time.Duration {
+	if s.EndedSessions == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.EndedSessions)
+}
+
+// AverageTime returns the average time spent in the section per view
+func (s SectionSummary) AverageTime() time.Duration {
+	if s.Views == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Views)
+}
+
+// AverageScroll returns the average scroll depth in percent
+func (s SectionSummary) AverageScroll() int {
+	if s.Views == 0 {
+		return 0
+	}
+	return s.TotalScroll / s.Views
+}
+
+// SummarizeAnalytics reads an analytics log and aggregates it. Events before
+// since are ignored; a zero since includes everything. Malformed lines are
+// counted and skipped, so a log cut off mid-write can still be read.
+func SummarizeAnalytics(r io.Reader, since time.Time) (*AnalyticsSummary, error) {
+	summary := &AnalyticsSummary{EndReasons: make(map[string]int)}
+	days := make(map[string]*DaySummary)
+	dayVisitors := make(map[string]map[string]bool)
+	sections := make(map[string]*SectionSummary)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event AnalyticsEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.Event == "" || event.Time.IsZero() {
+			summary.SkippedLines++
+			continue
+		}
+		if event.Time.Before(since) {
+			continue
+		}
+
+		if summary.First.IsZero() || event.Time.Before(summary.First) {
+			summary.First = event.Time
+		}
+		if event.Time.After(summary.Last) {
+			summary.Last = event.Time
+		}
+
+		switch event.Event {
+		case eventSessionStart:
+			summary.Sessions++
+			day := event.Time.UTC().Format(time.DateOnly)
+			if days[day] == nil {
+				days[day] = &DaySummary{Day: day}
+				dayVisitors[day] = make(map[string]bool)
+			}
+			days[day].Sessions++
+			if event.Visitor != "" && !dayVisitors[day][event.Visitor] {
+				dayVisitors[day][event.Visitor] = true
+				days[day].Visitors++
+				summary.Visitors++
+			}
+
+		case eventSectionLeave:
+			section := sections[event.Section]
+			if section == nil {
+				section = &SectionSummary{Section: event.Section}
+				sections[event.Section] = section
+			}
+			section.Views++
+			section.TotalTime += time.Duration(event.DurationMS) * time.Millisecond
+			section.TotalScroll += event.Scroll
+
+		case eventSessionEnd:
+			summary.EndedSessions++
+			summary.TotalDuration += time.Duration(event.DurationMS) * time.Millisecond
+			summary.EndReasons[event.Reason]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read analytics log: %w", err)
+	}
+
+	for _, day := range days {
+		summary.Days = append(summary.Days, *day)
+	}
+	sort.Slice(summary.Days, func(i, j int) bool {
+		return summary.Days[i].Day < summary.Days[j].Day
+	})
+
+	for _, section := range sections {
+		summary.Sections = append(summary.Sections, *section)
+	}
+	sort.Slice(summary.Sections, func(i, j int) bool {
+		a, b := summary.Sections[i], summary.Sections[j]
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		return a.Section < b.Section
+	})
+
+	return summary, nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sessionVisitors starts a session for each address and returns the visitor
+// ids they were logged with
+func sessionVisitors(t *testing.T, path string, remoteIPs ...string) []string {
+	t.Helper()
+	a, err := OpenAnalytics(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ip := range remoteIPs {
+		a.StartSession(randomID(), "ssh", ip, "xterm", 80, 24)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var visitors []string
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		var event AnalyticsEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		visitors = append(visitors, event.Visitor)
+	}
+	return visitors[len(visitors)-len(remoteIPs):]
+}
+
+func TestAnalyticsVisitorIDs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analytics.jsonl")
+
+	ids := sessionVisitors(t, path, "192.0.2.1", "192.0.2.1", "192.0.2.2")
+	if ids[0] == "" || strings.Contains(ids[0], "192.0.2.1") {
+		t.Fatalf("visitor id %q", ids[0])
+	}
+	if ids[1] != ids[0] {
+		t.Errorf("the same visitor got %q and %q", ids[0], ids[1])
+	}
+	if ids[2] == ids[0] {
+		t.Error("two addresses got the same visitor id")
+	}
+
+	// The salt is never written anywhere, so a restart starts a new one
+	if again := sessionVisitors(t, path, "192.0.2.1"); again[0] == ids[0] {
+		t.Error("the visitor id survived a restart")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("%d files next to the log, want only the log", len(entries))
+	}
+}
+
+func TestSummarizeAnalytics(t *testing.T) {
+	log := `{"time":"2024-05-01T10:00:00Z","event":"session_start","session":"a","visitor":"v1"}
+{"time":"2024-05-01T10:00:01Z","event":"section_enter","session":"a","section":"about"}
+{"time":"2024-05-01T10:00:11Z","event":"section_leave","session":"a","section":"about","duration_ms":10000,"scroll_depth":100}
+{"time":"2024-05-01T10:00:12Z","event":"session_end","session":"a","duration_ms":12000,"reason":"quit"}
+{"time":"2024-05-01T11:00:00Z","event":"session_start","session":"b","visitor":"v1"}
+{"time":"2024-05-01T11:00:04Z","event":"section_leave","session":"b","section":"about","duration_ms":4000,"scroll_depth":50}
+{"time":"2024-05-01T11:00:05Z","event":"section_leave","session":"b","section":"projects","duration_ms":1000}
+not json
+{"time":"2024-05-01T11:00:06Z","event":"session_end","session":"b","duration_ms":6000,"reason":"idle"
+{"event":"session_start","session":"c"}
+{"time":"2024-05-01T12:00:00Z","session":"d"}
+
+{"time":"2024-05-02T09:00:00Z","event":"session_start","session":"e","visitor":"v1"}
+{"time":"2024-05-02T09:00:01Z","event":"session_start","session":"f","visitor":"v2"}
+{"time":"2024-05-02T09:00:02Z","event":"session_end","session":"f","duration_ms":2000}
+`
+	summary, err := SummarizeAnalytics(strings.NewReader(log), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.SkippedLines != 4 {
+		t.Errorf("skipped %d lines, want 4", summary.SkippedLines)
+	}
+	if summary.Sessions != 4 || summary.Visitors != 3 {
+		t.Errorf("%d sessions and %d visitors, want 4 and 3", summary.Sessions, summary.Visitors)
+	}
+	if len(summary.Days) != 2 || summary.Days[0].Day != "2024-05-01" || summary.Days[0].Visitors != 1 || summary.Days[1].Sessions != 2 {
+		t.Errorf("days %+v", summary.Days)
+	}
+	if summary.EndedSessions != 2 || summary.AverageDuration() != 7*time.Second {
+		t.Errorf("%d ended sessions averaging %v, want 2 averaging 7s", summary.EndedSessions, summary.AverageDuration())
+	}
+	if summary.EndReasons["quit"] != 1 || summary.EndReasons[""] != 1 {
+		t.Errorf("end reasons %v", summary.EndReasons)
+	}
+	if len(summary.Sections) != 2 || summary.Sections[0].Section != "about" {
+		t.Fatalf("sections %+v", summary.Sections)
+	}
+	if about := summary.Sections[0]; about.Views != 2 || about.AverageTime() != 7*time.Second || about.AverageScroll() != 75 {
+		t.Errorf("about %+v", about)
+	}
+
+	// Only events since the given time count
+	since := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	summary, err = SummarizeAnalytics(strings.NewReader(log), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Sessions != 2 || len(summary.Days) != 1 || len(summary.Sections) != 0 || !summary.First.Equal(since.Add(9*time.Hour)) {
+		t.Errorf("since %v: %+v", since, summary)
+	}
+}
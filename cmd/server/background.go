@@ -0,0 +1,254 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backgroundQuery asks a terminal for its background color (OSC 11), then for
+// its device attributes (DA1). Every terminal answers DA1 and answers in
+// order, so a DA1 reply without a color reply before it means the color
+// can't be queried.
+const backgroundQuery = "\x1b]11;?\x07\x1b[c"
+
+// backgroundTimeout is how long replies to backgroundQuery are looked for
+const backgroundTimeout = 5 * time.Second
+
+// maxReplyLength bounds how much input is held back as the start of a reply
+const maxReplyLength = 64
+
+// replyGap is how long the start of a reply is held back waiting for the
+// rest; after that it is passed on as typed input
+const replyGap = 100 * time.Millisecond
+
+// backgroundMsg reports whether the visitor's terminal has a dark background
+type backgroundMsg struct {
+	dark bool
+}
+
+// backgroundDetector takes the replies to backgroundQuery out of a session's
+// input, reporting the background color they carry
+type backgroundDetector struct {
+	found   func(dark bool)
+	timeout time.Duration // How long replies are looked for
+	done    bool          // Whether replies are no longer looked for
+	pending []byte        // Start of a reply, held back until the rest arrives
+}
+
+// detectBackground returns a session's input with the replies to
+// backgroundQuery taken out, and a channel that receives the background's
+// darkness if the terminal reports its color. Reads from a session can't be
+// cancelled, so rather than waiting for the replies before the program
+// starts, they are picked out of the program's own input: a terminal that
+// never answers costs nothing, and no keystroke is lost. The query has to be
+// sent separately, before the program starts writing. Input stops when ctx
+// is done.
+func detectBackground(ctx context.Context, r io.Reader) (io.Reader, <-chan bool) {
+	input, output := io.Pipe()
+	chunks := make(chan []byte)
+	readErr := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		buf := make([]byte, 1024)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				select {
+				case chunks <- bytes.Clone(buf[:n]):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		input.Close()
+	}()
+
+	found := make(chan bool, 1)
+	d := &backgroundDetector{
+		found: func(dark bool) {
+			select {
+			case found <- dark:
+			default:
+			}
+		},
+		timeout: backgroundTimeout,
+	}
+	go func() {
+		d.run(chunks, output)
+		// The reader has only finished if it closed chunks
+		var err error
+		select {
+		case err = <-readErr:
+		default:
+		}
+		output.CloseWithError(err)
+	}()
+	return input, found
+}
+
+// run passes chunks of input on to w until they run out, taking replies out
+// until the device attributes reply or the timeout
+func (d *backgroundDetector) run(chunks <-chan []byte, w io.Writer) {
+	timeout := time.After(d.timeout)
+	var gap <-chan time.Time
+
+	for {
+		var data []byte
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				_, _ = w.Write(d.pending)
+				return
+			}
+			data = append(d.pending, chunk...)
+			d.pending = nil
+			if !d.done {
+				data = d.scan(data)
+			}
+			gap = nil
+			if len(d.pending) > 0 {
+				gap = time.After(replyGap)
+			}
+
+		case <-gap:
+			// Not a reply after all
+			data, d.pending = d.pending, nil
+
+		case <-timeout:
+			d.done = true
+			data, d.pending = d.pending, nil
+		}
+
+		if len(data) > 0 {
+			if _, err := w.Write(data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// scan removes replies from data, reporting the background color. A reply
+// cut off at the end of data is kept in pending.
+func (d *backgroundDetector) scan(data []byte) []byte {
+	var out []byte
+	for !d.done {
+		start := bytes.IndexByte(data, '\x1b')
+		if start < 0 {
+			break
+		}
+		out = append(out, data[:start]...)
+		data = data[start:]
+
+		switch {
+		case bytes.HasPrefix(data, []byte("\x1b]11;")):
+			end, next := oscEnd(data)
+			if end < 0 && len(data) < maxReplyLength {
+				d.pending = data
+				return out
+			}
+			if end < 0 {
+				// Too long to be a reply
+				out = append(out, data[0])
+				data = data[1:]
+				continue
+			}
+			if dark, ok := parseBackgroundColor(string(data[len("\x1b]11;"):end])); ok {
+				d.found(dark)
+			}
+			data = data[next:]
+
+		case bytes.HasPrefix(data, []byte("\x1b[?")):
+			end := bytes.IndexFunc(data[3:], func(r rune) bool {
+				return (r < '0' || r > '9') && r != ';'
+			})
+			if end < 0 && len(data) < maxReplyLength {
+				d.pending = data
+				return out
+			}
+			if end < 0 || data[3+end] != 'c' {
+				// Not a reply; leave it for the program
+				out = append(out, data[0])
+				data = data[1:]
+				continue
+			}
+			// The DA1 reply comes last, so there's nothing more to look for
+			data = data[3+end+1:]
+			d.done = true
+
+		case isReplyPrefix(data):
+			d.pending = data
+			return out
+
+		default:
+			out = append(out, data[0])
+			data = data[1:]
+		}
+	}
+	return append(out, data...)
+}
+
+// isReplyPrefix reports whether data could be the start of a reply cut off
+// by the end of a read
+func isReplyPrefix(data []byte) bool {
+	for _, reply := range []string{"\x1b]11;", "\x1b[?"} {
+		if len(data) < len(reply) && strings.HasPrefix(reply, string(data)) {
+			return true
+		}
+	}
+	return false
+}
+
+// oscEnd returns where an OSC sequence's terminator (BEL or ESC \) starts and
+// ends, or -1 if data doesn't hold all of it
+func oscEnd(data []byte) (end, next int) {
+	for i, b := range data {
+		switch {
+		case b == '\a':
+			return i, i + 1
+		case b == '\x1b' && i > 0 && i+1 < len(data) && data[i+1] == '\\':
+			return i, i + 2
+		}
+	}
+	return -1, -1
+}
+
+// parseBackgroundColor reads a color reply such as rgb:1e1e/1e1e/2e2e and
+// reports whether the color is dark, going by its lightness
+func parseBackgroundColor(value string) (dark, ok bool) {
+	channels, ok := strings.CutPrefix(value, "rgb:")
+	if !ok {
+		return false, false
+	}
+	parts := strings.Split(channels, "/")
+	if len(parts) != 3 {
+		return false, false
+	}
+
+	var lo, hi float64 = 1, 0
+	for _, part := range parts {
+		if len(part) == 0 || len(part) > 4 {
+			return false, false
+		}
+		n, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return false, false
+		}
+		// Channels have 1 to 4 hex digits
+		c := float64(n) / float64(uint64(1)<<(4*len(part))-1)
+		lo, hi = min(lo, c), max(hi, c)
+	}
+	return (lo+hi)/2 < 0.5, true
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// runDetector feeds chunks through a detector with the given timeout, waiting
+// between them, and returns what it passed on and the darkness it found
+func runDetector(timeout time.Duration, chunks []string, wait time.Duration) (string, []bool) {
+	var found []bool
+	d := &backgroundDetector{
+		found:   func(dark bool) { found = append(found, dark) },
+		timeout: timeout,
+	}
+
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for _, chunk := range chunks {
+			in <- []byte(chunk)
+			time.Sleep(wait)
+		}
+	}()
+
+	var out bytes.Buffer
+	d.run(in, &out)
+	return out.String(), found
+}
+
+func TestBackgroundDetector(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   string
+		found  []bool
+	}{
+		{
+			name:   "dark reply",
+			chunks: []string{"\x1b]11;rgb:1e1e/1e1e/2e2e\a\x1b[?62;22c"},
+			found:  []bool{true},
+		},
+		{
+			name:   "light reply with ST",
+			chunks: []string{"\x1b]11;rgb:eeee/eeee/eeee\x1b\\\x1b[?1;2c"},
+			found:  []bool{false},
+		},
+		{
+			name:   "mixed channel widths",
+			chunks: []string{"\x1b]11;rgb:ffff/ff/f\a\x1b[?1;2c"},
+			found:  []bool{false},
+		},
+		{
+			name:   "reply split across reads",
+			chunks: []string{"\x1b", "]11;rgb:00", "00/0000/0000\x1b", "\\\x1b[", "?6", "2c"},
+			found:  []bool{true},
+		},
+		{
+			name:   "keys around the replies",
+			chunks: []string{"j\x1b]11;rgb:0/0/0\ak", "\x1b[?1;2cq"},
+			want:   "jkq",
+			found:  []bool{true},
+		},
+		{
+			name:   "no color before device attributes",
+			chunks: []string{"\x1b[?1;2c"},
+		},
+		{
+			name:   "input after device attributes",
+			chunks: []string{"\x1b[?1;2c", "\x1b]11;rgb:0/0/0\a"},
+			want:   "\x1b]11;rgb:0/0/0\a",
+		},
+		{
+			name:   "arrow keys",
+			chunks: []string{"\x1b[A\x1b[B"},
+			want:   "\x1b[A\x1b[B",
+		},
+		{
+			name:   "bad color",
+			chunks: []string{"\x1b]11;cmyk:1/2/3/4\a\x1b[?1;2c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := runDetector(time.Second, tt.chunks, time.Millisecond)
+			if got != tt.want {
+				t.Errorf("passed on %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(found, tt.found) {
+				t.Errorf("found %v, want %v", found, tt.found)
+			}
+		})
+	}
+}
+
+func TestBackgroundDetectorEscapeKey(t *testing.T) {
+	// A lone escape might start a reply, but is passed on after replyGap
+	got, found := runDetector(time.Second, []string{"\x1b", "x"}, 2*replyGap)
+	if got != "\x1bx" || len(found) > 0 {
+		t.Errorf("got %q and %v, want the escape passed on", got, found)
+	}
+}
+
+func TestBackgroundDetectorTimeout(t *testing.T) {
+	// Once the timeout passes, replies are left in the input
+	reply := "\x1b]11;rgb:0/0/0\a"
+	got, found := runDetector(10*time.Millisecond, []string{"a", reply}, 50*time.Millisecond)
+	if got != "a"+reply || len(found) > 0 {
+		t.Errorf("got %q and %v, want the reply passed on", got, found)
+	}
+}
+
+func TestDetectBackground(t *testing.T) {
+	r := iotest.OneByteReader(strings.NewReader("\x1b]11;rgb:ffff/ffff/ffff\a\x1b[?1;2chello"))
+	input, found := detectBackground(context.Background(), r)
+
+	got, err := io.ReadAll(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("input %q, want %q", got, "hello")
+	}
+	select {
+	case dark := <-found:
+		if dark {
+			t.Error("white reported as dark")
+		}
+	default:
+		t.Error("no background found")
+	}
+}
+
+func TestParseBackgroundColor(t *testing.T) {
+	tests := []struct {
+		value    string
+		dark, ok bool
+	}{
+		{"rgb:0000/0000/0000", true, true},
+		{"rgb:ffff/ffff/ffff", false, true},
+		{"rgb:ffff/ff/f", false, true},
+		{"rgb:1e/1e/2e", true, true},
+		{"rgb:fdfd/f6f6/e3e3", false, true},
+		{"rgb:80/80/80", false, true},
+		{"rgb:7f/7f/7f", true, true},
+		{"rgb:ffff/ffff", false, false},
+		{"rgb:fffff/0/0", false, false},
+		{"rgb:gg/00/00", false, false},
+		{"rgb://", false, false},
+		{"#ffffff", false, false},
+	}
+
+	for _, tt := range tests {
+		dark, ok := parseBackgroundColor(tt.value)
+		if dark != tt.dark || ok != tt.ok {
+			t.Errorf("parseBackgroundColor(%q) = %v, %v, want %v, %v", tt.value, dark, ok, tt.dark, tt.ok)
+		}
+	}
+}
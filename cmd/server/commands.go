@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/bubbletea"
+)
+
+// commandHelp lists the commands available as `ssh host <command>`. The
+// enabled sections are inserted before the other commands.
+const commandHelp = `Usage: ssh %[1]s <command>
+
+Commands:
+%[2]s  json         All portfolio data as JSON
+  vcard        Contact card, e.g. ssh %[1]s vcard > contact.vcf
+  help         Show this help
+
+Connect without a command for the interactive portfolio.
+`
+
+// CommandMiddleware answers non-interactive sessions such as
+// `ssh host skills` and exits, so the portfolio can be used from scripts.
+// Sessions without a command get the TUI, or the whole portfolio as plain
+// text if they have no PTY to run it in.
+func CommandMiddleware(config *ServerConfig) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			args := s.Command()
+			if len(args) == 0 {
+				if _, _, ok := s.Pty(); ok {
+					next(s)
+					return
+				}
+				args = []string{"all"}
+			}
+
+			if err := runCommand(s, config, args); err != nil {
+				wish.Errorln(s, err)
+				wish.Errorf(s, "%s", commandUsage(config))
+				_ = s.Exit(1)
+				return
+			}
+			_ = s.Exit(0)
+		}
+	}
+}
+
+// runCommand writes the output of a single command to the session
+func runCommand(s ssh.Session, config *ServerConfig, args []string) error {
+	name := strings.ToLower(args[0])
+	if len(args) > 1 {
+		return fmt.Errorf("%s takes no arguments", name)
+	}
+
+	if section, ok := lookupTextSection(config, name); ok {
+		renderer, width := commandRenderer(s)
+		_, err := io.WriteString(s, renderText(config, []Section{section}, width, renderer, envAccessible(s.Environ(), config.Accessible), ""))
+		return err
+	}
+
+	switch name {
+	case "all":
+		renderer, width := commandRenderer(s)
+		hint := "No terminal was allocated, so this is the plain text version.\n" +
+			"For the interactive portfolio, connect with: ssh -t " + commandHost(config)
+		_, err := io.WriteString(s, renderText(config, allTextSections(config), width, renderer, envAccessible(s.Environ(), config.Accessible), hint))
+		return err
+	case "json":
+		return Export(s, config.DataLoader.Snapshot(), ExportJSON, nil)
+	case "vcard":
+		return Export(s, config.DataLoader.Snapshot(), ExportVCard, nil)
+	case "help", "-h", "--help":
+		wish.Printf(s, "%s", commandUsage(config))
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// commandUsage returns the help for the commands this server answers
+func commandUsage(config *ServerConfig) string {
+	var sections strings.Builder
+	for _, s := range textSections {
+		if config.sectionEnabled(s.section) {
+			fmt.Fprintf(&sections, "  %-12s %s\n", s.name, s.description)
+		}
+	}
+	return fmt.Sprintf(commandHelp, commandHost(config), sections.String())
+}
+
+// commandRenderer returns a renderer and width matching the client's
+// terminal. Without a PTY the output is plain text, so it can be piped.
+func commandRenderer(s ssh.Session) (*lipgloss.Renderer, int) {
+	width := textDefaultWidth
+	if pty, _, ok := s.Pty(); ok && pty.Window.Width > 0 {
+		width = pty.Window.Width - offsetWindowWidth
+	}
+	return bubbletea.MakeRenderer(s), width
+}
+
+// commandHost returns the address to show in usage examples
+func commandHost(config *ServerConfig) string {
+	host := config.Host
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "host"
+	}
+	if config.Port != 22 {
+		return fmt.Sprintf("%s -p %d", host, config.Port)
+	}
+	return host
+}
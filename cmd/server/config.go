@@ -0,0 +1,307 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envPrefix starts the name of every environment variable read by ApplyEnv
+const envPrefix = "PORTFOLIO_"
+
+// Config holds the server settings. They are merged from defaults, a config
+// file (LoadConfigFile), PORTFOLIO_* environment variables (ApplyEnv) and
+// command line flags, each overriding the one before, and checked with
+// Validate before use.
+type Config struct {
+	Host     string   `json:"host" yaml:"host" toml:"host"`
+	Port     uint     `json:"port" yaml:"port" toml:"port"`
+	HostKeys []string `json:"host_keys" yaml:"host_keys" toml:"host_keys"` // SSH host keys, generated if missing
+
+	Data       string   `json:"data" yaml:"data" toml:"data"`
+	DataFormat string   `json:"data_format" yaml:"data_format" toml:"data_format"`
+	Watch      Duration `json:"watch" yaml:"watch" toml:"watch"`
+
+	HTTP      string `json:"http" yaml:"http" toml:"http"`
+	Metrics   string `json:"metrics" yaml:"metrics" toml:"metrics"`
+	Analytics string `json:"analytics" yaml:"analytics" toml:"analytics"`
+
+	Sections []string `json:"sections" yaml:"sections" toml:"sections"` // Navigation sections, in tab order
+	Effects  bool     `json:"effects" yaml:"effects" toml:"effects"`    // Whether particle effects start enabled
+	Theme    string   `json:"theme" yaml:"theme" toml:"theme"`          // Default theme, overriding the data file's
+	Themes   string   `json:"themes" yaml:"themes" toml:"themes"`       // Directory of user theme files
+
+	// Whether sessions start in accessible mode. Visitors can also ask for it
+	// with PORTFOLIO_A11Y in their SSH environment, which takes precedence.
+	Accessible bool `json:"accessible" yaml:"accessible" toml:"accessible"`
+
+	Limits LimitsConfig `json:"limits" yaml:"limits" toml:"limits"`
+	Log    LogConfig    `json:"log" yaml:"log" toml:"log"`
+}
+
+// LimitsConfig is the config file form of SessionLimits
+type LimitsConfig struct {
+	MaxSessions int      `json:"max_sessions" yaml:"max_sessions" toml:"max_sessions"`
+	RateLimit   int      `json:"rate_limit" yaml:"rate_limit" toml:"rate_limit"`
+	MaxDuration Duration `json:"max_duration" yaml:"max_duration" toml:"max_duration"`
+	IdleTimeout Duration `json:"idle_timeout" yaml:"idle_timeout" toml:"idle_timeout"`
+}
+
+// LogConfig selects the log level and output format
+type LogConfig struct {
+	Level  string `json:"level" yaml:"level" toml:"level"`
+	Format string `json:"format" yaml:"format" toml:"format"`
+}
+
+// Duration is a time.Duration written as a string like "30m" in config files
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// LoadConfigFile merges a TOML, YAML or JSON config file into c. Settings
+// missing from the file keep their current values; unknown settings are an
+// error so typos don't go unnoticed.
+func (c *Config) LoadConfigFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := decodeSettings(path, raw, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// decodeSettings decodes a TOML, YAML or JSON settings file, picked by the
+// extension of path, into v. Unknown fields are an error.
+func decodeSettings(path string, raw []byte, v any) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return decodeTOML(raw, v, true)
+	case ".yaml", ".yml":
+		return decodeYAML(raw, v, true)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	default:
+		return errors.New("unsupported file type (expected .toml, .yaml, .yml or .json)")
+	}
+}
+
+// ApplyEnv overrides settings with PORTFOLIO_* environment variables, read
+// through lookup (usually os.LookupEnv). Lists are comma separated.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	env := func(name string, apply func(string) error) {
+		value, ok := lookup(envPrefix + name)
+		if !ok {
+			return
+		}
+		if err := apply(strings.TrimSpace(value)); err != nil {
+			errs = append(errs, fmt.Errorf("%s%s: %w", envPrefix, name, err))
+		}
+	}
+
+	env("HOST", setString(&c.Host))
+	env("PORT", setUint(&c.Port))
+	env("HOST_KEYS", setList(&c.HostKeys))
+	env("DATA", setString(&c.Data))
+	env("DATA_FORMAT", setString(&c.DataFormat))
+	env("WATCH", setDuration(&c.Watch))
+	env("HTTP", setString(&c.HTTP))
+	env("METRICS", setString(&c.Metrics))
+	env("ANALYTICS", setString(&c.Analytics))
+	env("SECTIONS", setList(&c.Sections))
+	env("EFFECTS", setBool(&c.Effects))
+	env("THEME", setString(&c.Theme))
+	env("THEMES", setString(&c.Themes))
+	env("ACCESSIBLE", setBool(&c.Accessible))
+	env("MAX_SESSIONS", setInt(&c.Limits.MaxSessions))
+	env("RATE_LIMIT", setInt(&c.Limits.RateLimit))
+	env("MAX_DURATION", setDuration(&c.Limits.MaxDuration))
+	env("IDLE_TIMEOUT", setDuration(&c.Limits.IdleTimeout))
+	env("LOG_LEVEL", setString(&c.Log.Level))
+	env("LOG_FORMAT", setString(&c.Log.Format))
+
+	return errors.Join(errs...)
+}
+
+// Validate checks every setting and reports all problems at once. The theme
+// is looked up among the built-in themes and userThemes, the themes loaded
+// from c.Themes (see LoadThemes).
+func (c *Config) Validate(userThemes []*Theme) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Port == 0 || c.Port > 65535 {
+		fail("port %d is out of range (1-65535)", c.Port)
+	}
+	if len(c.HostKeys) == 0 {
+		fail("at least one host key path is required")
+	}
+	for _, path := range c.HostKeys {
+		if strings.TrimSpace(path) == "" {
+			fail("host key paths can't be empty")
+		}
+	}
+
+	if c.Data == "" {
+		fail("data path is required")
+	}
+	if _, err := ParseDataFormat(c.DataFormat); err != nil {
+		fail("data_format: %v", err)
+	}
+	if c.Watch < 0 {
+		fail("watch interval can't be negative")
+	}
+
+	for _, listener := range []struct{ name, addr string }{{"http", c.HTTP}, {"metrics", c.Metrics}} {
+		if listener.addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(listener.addr); err != nil {
+			fail("%s address %q: %v", listener.name, listener.addr, err)
+		}
+	}
+	if c.HTTP != "" && c.HTTP == c.Metrics {
+		fail("http and metrics can't share the address %s", c.HTTP)
+	}
+
+	if _, err := c.sections(); err != nil {
+		errs = append(errs, err)
+	}
+	available := slices.Concat(builtinThemes, userThemes)
+	if _, ok := findTheme(available, c.Theme); c.Theme != "" && !ok {
+		fail("theme: unknown theme %q (expected %s)", c.Theme, strings.Join(themeNames(available), ", "))
+	}
+
+	if c.Limits.MaxSessions < 0 || c.Limits.RateLimit < 0 || c.Limits.MaxDuration < 0 || c.Limits.IdleTimeout < 0 {
+		fail("limits can't be negative; use 0 to disable a limit")
+	}
+
+	if _, err := NewLogger(io.Discard, c.Log.Level, c.Log.Format); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// SessionLimits returns the configured session limits
+func (c *Config) SessionLimits() SessionLimits {
+	return SessionLimits{
+		MaxSessions:   c.Limits.MaxSessions,
+		RatePerMinute: c.Limits.RateLimit,
+		MaxDuration:   time.Duration(c.Limits.MaxDuration),
+		IdleTimeout:   time.Duration(c.Limits.IdleTimeout),
+	}
+}
+
+// sections returns the enabled navigation sections in tab order
+func (c *Config) sections() ([]Section, error) {
+	if len(c.Sections) == 0 {
+		return nil, errors.New("sections: at least one section must be enabled")
+	}
+
+	sections := make([]Section, 0, len(c.Sections))
+	seen := make(map[Section]bool)
+	for _, name := range c.Sections {
+		section, ok := parseSection(name)
+		if !ok || section == HelpSection {
+			return nil, fmt.Errorf("sections: unknown section %q (expected %s)", name,
+				strings.Join(sectionNames[:HelpSection], ", "))
+		}
+		if seen[section] {
+			return nil, fmt.Errorf("sections: %q is listed twice", name)
+		}
+		seen[section] = true
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+func setString(dst *string) func(string) error {
+	return func(value string) error {
+		*dst = value
+		return nil
+	}
+}
+
+func setList(dst *[]string) func(string) error {
+	return func(value string) error {
+		*dst = SplitList(value)
+		return nil
+	}
+}
+
+func setUint(dst *uint) func(string) error {
+	return func(value string) error {
+		n, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*dst = uint(n)
+		return nil
+	}
+}
+
+func setInt(dst *int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*dst = n
+		return nil
+	}
+}
+
+func setBool(dst *bool) func(string) error {
+	return func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		*dst = b
+		return nil
+	}
+}
+
+func setDuration(dst *Duration) func(string) error {
+	return func(value string) error {
+		return dst.UnmarshalText([]byte(value))
+	}
+}
+
+// SplitList splits a comma separated list, dropping empty entries
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
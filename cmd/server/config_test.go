@@ -0,0 +1,138 @@
+package server
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig returns settings that pass Validate
+func validConfig() Config {
+	return Config{
+		Port:     2222,
+		HostKeys: []string{".ssh/key"},
+		Data:     "data/portfolio.json",
+		Sections: NavigationSectionNames(),
+		Log:      LogConfig{Level: "info", Format: "text"},
+	}
+}
+
+func TestLoadConfigFileUnknownSettings(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file    string
+		content string
+		want    string // Part of the error, or "" for none
+	}{
+		{"ok.toml", "port = 3000\nwatch = \"1m\"\n[limits]\nmax_sessions = 2\n", ""},
+		{"ok.yaml", "port: 3000\nwatch: 1m\nlimits:\n  max_sessions: 2\n", ""},
+		{"ok.json", `{"port": 3000, "watch": "1m", "limits": {"max_sessions": 2}}`, ""},
+		{"typo.toml", "prot = 3000\n", `unknown field "prot"`},
+		{"typo.yaml", "limits:\n  max_session: 2\n", `unknown field "max_session"`},
+		{"typo.json", `{"log": {"lvl": "debug"}}`, `unknown field "lvl"`},
+		{"wrong.toml", `port = "many"`, "port"},
+		{"wrong.yaml", "port: many\n", "yaml: line 1: "},
+		{"config.ini", "port=3000", "unsupported file type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeFile(t, path, tt.content)
+
+			cfg := validConfig()
+			err := cfg.LoadConfigFile(path)
+			switch {
+			case tt.want == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.want == "" && (cfg.Port != 3000 || cfg.Watch != Duration(time.Minute) || cfg.Limits.MaxSessions != 2):
+				t.Errorf("settings not loaded: %+v", cfg)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("error %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileKeepsMissingSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeFile(t, path, "host = \"example.com\"\n")
+
+	cfg := validConfig()
+	if err := cfg.LoadConfigFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "example.com" || cfg.Port != 2222 || cfg.Data != "data/portfolio.json" {
+		t.Errorf("got %+v", cfg)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"PORTFOLIO_PORT":       " 3000 ",
+		"PORTFOLIO_SECTIONS":   "about,,projects",
+		"PORTFOLIO_EFFECTS":    "false",
+		"PORTFOLIO_WATCH":      "soon",
+		"PORTFOLIO_RATE_LIMIT": "-",
+	}
+	cfg := validConfig()
+	cfg.Effects = true
+	err := cfg.ApplyEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	})
+
+	if cfg.Port != 3000 || cfg.Effects || strings.Join(cfg.Sections, ",") != "about,projects" {
+		t.Errorf("got %+v", cfg)
+	}
+	// Every bad variable is reported
+	if err == nil || !strings.Contains(err.Error(), "PORTFOLIO_WATCH") || !strings.Contains(err.Error(), "PORTFOLIO_RATE_LIMIT") {
+		t.Errorf("error %v, want both bad variables", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	userThemes := []*Theme{{Name: "mine", Dark: true}}
+
+	tests := []struct {
+		name   string
+		change func(*Config)
+		want   []string // Parts of the error, none for a valid config
+	}{
+		{"valid", func(*Config) {}, nil},
+		{"built-in theme", func(c *Config) { c.Theme = "Nord" }, nil},
+		{"user theme", func(c *Config) { c.Theme = "mine" }, nil},
+		{"unknown theme", func(c *Config) { c.Theme = "yours" }, []string{`unknown theme "yours"`, "mine"}},
+		{"port", func(c *Config) { c.Port = 0 }, []string{"port 0"}},
+		{"sections", func(c *Config) { c.Sections = []string{"about", "about"} }, []string{"listed twice"}},
+		{"addresses", func(c *Config) { c.HTTP, c.Metrics = ":8080", ":8080" }, []string{"share the address"}},
+		{"every problem", func(c *Config) {
+			c.Data = ""
+			c.DataFormat = "xml"
+			c.Limits.RateLimit = -1
+		}, []string{"data path", "data_format", "negative"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.change(&cfg)
+			err := cfg.Validate(userThemes)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("no error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -5,17 +5,24 @@ import (
 	"math"
 	"strings"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func (m *PortfolioModel) renderAbout() string {
 	var content strings.Builder
 
 	// Get data from loader or use fallback
-	personal := m.dataLoader.GetPersonalInfo()
-	asciiArt := m.dataLoader.GetAsciiArt()
+	personal := m.data.GetPersonalInfo()
+	asciiArt := m.data.GetAsciiArt()
 
-	// Render ASCII art
-	if asciiArt != nil && asciiArt.Logo != "" {
+	// Render ASCII art, or just the name for screen readers
+	if m.accessible {
+		if personal != nil {
+			content.WriteString(m.styles.Header.Render(personal.Name))
+		}
+	} else if asciiArt != nil && asciiArt.Logo != "" {
 		content.WriteString(m.styles.AsciiArt.Render(asciiArt.Logo))
 	} else {
 		// Fallback ASCII art
@@ -42,21 +49,21 @@ func (m *PortfolioModel) renderAbout() string {
 		about.WriteString("\n")
 
 		if personal.About.Intro != "" {
-			about.WriteString(personal.About.Intro)
+			about.WriteString(m.renderMarkdownText(personal.About.Intro, 0, m.styles.ContentText))
 			about.WriteString("\n\n")
 		}
 
 		// What I Do section
 		about.WriteString("🎯 What I Do:\n")
 		if personal.About.WhatIDo != "" {
-			about.WriteString("\t" + personal.About.WhatIDo + "\n\n")
+			about.WriteString(m.renderMarkdownText(personal.About.WhatIDo, markdownIndent, m.styles.ContentText) + "\n\n")
 		}
 
 		// Background section
 		if len(personal.About.Background) > 0 {
 			about.WriteString("💻 Background:\n")
 			for _, bg := range personal.About.Background {
-				about.WriteString("\t• " + bg + "\n")
+				about.WriteString(m.renderMarkdownBullet(bg, markdownIndent, m.styles.ContentText) + "\n")
 			}
 			about.WriteString("\n")
 		}
@@ -64,7 +71,7 @@ func (m *PortfolioModel) renderAbout() string {
 		// Philosophy section
 		if personal.About.Philosophy != "" {
 			about.WriteString("🌱 Always Learning:\n")
-			about.WriteString("\t" + personal.About.Philosophy + "\n")
+			about.WriteString(m.renderMarkdownText(personal.About.Philosophy, markdownIndent, m.styles.ContentText) + "\n")
 		}
 	} else {
 		// Fallback content if no data is loaded
@@ -91,7 +98,7 @@ Welcome to my interactive portfolio terminal!
 	// Add random tech facts
 	content.WriteString("\n")
 
-	fact := m.dataLoader.GetRandomTechFact(m.animationTick / 50)
+	fact := m.data.GetRandomTechFact(m.animationTick / 50)
 	content.WriteString(m.styles.FactBox.Render("💡 " + fact))
 
 	// Add real-time clock
@@ -112,7 +119,7 @@ func (m *PortfolioModel) renderExperience() string {
 	content.WriteString(m.styles.SectionTitle.Render("💼 Professional Experience"))
 	content.WriteString("\n\n")
 
-	experiences := m.dataLoader.GetExperiences()
+	experiences := m.data.GetExperiences()
 
 	if len(experiences) == 0 {
 		// Fallback content
@@ -129,15 +136,19 @@ func (m *PortfolioModel) renderExperience() string {
 		content.WriteString(m.styles.ExperienceTitle.Render(header))
 		content.WriteString("\n")
 
-		meta := fmt.Sprintf("📅 %s • 📍 %s", exp.Period, exp.Location)
+		meta := []string{m.label("📅", "Period", exp.Period), m.label("📍", "Location", exp.Location)}
 		if exp.Current {
-			meta += " • 🟢 Current"
+			current := "🟢 Current"
+			if m.accessible {
+				current = "Current position."
+			}
+			meta = append(meta, current)
 		}
-		content.WriteString(m.styles.ExperienceMeta.Render(meta))
+		content.WriteString(m.styles.ExperienceMeta.Render(m.joinMeta(meta)))
 		content.WriteString("\n\n")
 
 		for _, detail := range exp.Details {
-			content.WriteString(m.styles.ExperienceDetail.Render("  • " + detail))
+			content.WriteString(m.styles.ExperienceDetail.Render(m.renderMarkdownBullet(detail, 2, m.styles.ExperienceDetail)))
 			content.WriteString("\n")
 		}
 
@@ -159,7 +170,7 @@ func (m *PortfolioModel) renderSkills() string {
 	content.WriteString(m.styles.SectionTitle.Render("🛠️ Technical Skills"))
 	content.WriteString("\n\n")
 
-	skillCategories := m.dataLoader.GetSkills()
+	skillCategories := m.data.GetSkills()
 
 	if len(skillCategories) == 0 {
 		// Fallback content
@@ -184,9 +195,14 @@ func (m *PortfolioModel) renderSkills() string {
 func (m *PortfolioModel) renderSkillBar(skill Skill) string {
 	barWidth := 30
 
+	// A bar is read out as a row of block characters, so give the number alone
+	if m.accessible {
+		return fmt.Sprintf("  %s: %d%% (%s)", skill.Name, skill.Percentage, skill.Experience)
+	}
+
 	// Add subtle animation to skill bars
 	animatedPercentage := skill.Percentage
-	if m.effectsEnabled {
+	if m.animated() {
 		// Gentle pulsing effect
 		pulse := int(3 * math.Sin(float64(m.animationTick+skill.Percentage)*0.05))
 		animatedPercentage = max(min(skill.Percentage+pulse, 100), 0)
@@ -195,8 +211,8 @@ func (m *PortfolioModel) renderSkillBar(skill Skill) string {
 	filled := int(float64(barWidth) * float64(animatedPercentage) / 100.0)
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
-	skillLine := fmt.Sprintf("  %-15s %s %3d%% (%s)",
-		skill.Name,
+	skillLine := fmt.Sprintf("  %-*s %s %3d%% (%s)",
+		skillNameWidth, skill.Name,
 		m.styles.SkillBar.Render(bar),
 		skill.Percentage,
 		skill.Experience,
@@ -205,15 +221,90 @@ func (m *PortfolioModel) renderSkillBar(skill Skill) string {
 	return skillLine
 }
 
+func (m *PortfolioModel) renderProjects() string {
+	var content strings.Builder
+
+	content.WriteString(m.styles.SectionTitle.Render("📦 Projects"))
+	content.WriteString("\n\n")
+
+	projects := m.data.GetProjects()
+
+	if len(projects) == 0 {
+		// Fallback content
+		content.WriteString(m.styles.ContentText.Render("No project data available. Please check the data file."))
+		return content.String()
+	}
+
+	for i, project := range projects {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+
+		content.WriteString(m.styles.ProjectTitle.Render(project.Name))
+		content.WriteString("\n")
+
+		var meta []string
+		if project.Status != "" {
+			meta = append(meta, m.label("🏷️", "Status", project.Status))
+		}
+		if period := projectPeriod(project); period != "" {
+			meta = append(meta, m.label("📅", "Period", period))
+		}
+		if len(meta) > 0 {
+			content.WriteString(m.styles.ExperienceMeta.Render(m.joinMeta(meta)))
+			content.WriteString("\n\n")
+		}
+
+		content.WriteString(m.renderMarkdownText(project.Description, 0, m.styles.ProjectDescription))
+		content.WriteString("\n\n")
+
+		if len(project.Highlights) > 0 {
+			content.WriteString(m.styles.ProjectLabel.Render("Highlights:"))
+			content.WriteString("\n")
+			for _, highlight := range project.Highlights {
+				content.WriteString(m.renderMarkdownBullet(highlight, 2, m.styles.ContentText))
+				content.WriteString("\n")
+			}
+		}
+
+		if len(project.Stack) > 0 {
+			content.WriteString(m.styles.ProjectLabel.Render("Stack: "))
+			content.WriteString(m.styles.ContentText.UnsetMarginBottom().Render(strings.Join(project.Stack, ", ")))
+			content.WriteString("\n")
+		}
+
+		if project.RepoURL != "" {
+			content.WriteString(m.styles.ProjectLabel.Render("Repo:  "))
+			content.WriteString(m.styles.ContentText.UnsetMarginBottom().Render(project.RepoURL))
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// projectPeriod formats the start and end dates of a project
+func projectPeriod(project Project) string {
+	switch {
+	case project.StartDate != "" && project.EndDate != "":
+		return project.StartDate + " - " + project.EndDate
+	case project.StartDate != "":
+		return project.StartDate + " - Present"
+	default:
+		return project.EndDate
+	}
+}
+
 func (m *PortfolioModel) renderContact() string {
 	var content strings.Builder
 
 	content.WriteString(m.styles.SectionTitle.Render("📞 Get In Touch"))
 	content.WriteString("\n\n")
 
-	contact := m.dataLoader.GetContact()
-	personal := m.dataLoader.GetPersonalInfo()
-	asciiArt := m.dataLoader.GetAsciiArt()
+	contact := m.data.GetContact()
+	personal := m.data.GetPersonalInfo()
+	asciiArt := m.data.GetAsciiArt()
 
 	if contact == nil {
 		// Fallback content
@@ -246,7 +337,7 @@ func (m *PortfolioModel) renderContact() string {
 	if len(contact.AvailableFor) > 0 {
 		contactContent.WriteString("Feel free to reach out for:\n")
 		for _, item := range contact.AvailableFor {
-			contactContent.WriteString("• " + item + "\n")
+			contactContent.WriteString(m.renderMarkdownBullet(item, 0, m.styles.ContentText) + "\n")
 		}
 		contactContent.WriteString("\n")
 	}
@@ -255,13 +346,16 @@ func (m *PortfolioModel) renderContact() string {
 	if len(contact.Specializations) > 0 {
 		contactContent.WriteString("🚀 Specializations:\n")
 		for _, spec := range contact.Specializations {
-			contactContent.WriteString("• " + spec + "\n")
+			contactContent.WriteString(m.renderMarkdownBullet(spec, 0, m.styles.ContentText) + "\n")
 		}
 	}
 
 	content.WriteString(m.styles.ContentText.Render(contactContent.String()))
 
 	// Add ASCII art
+	if m.accessible {
+		return content.String()
+	}
 	content.WriteString("\n\n")
 	if asciiArt != nil && asciiArt.Contact != "" {
 		content.WriteString(m.styles.AsciiArt.Render(asciiArt.Contact))
@@ -283,3 +377,115 @@ func (m *PortfolioModel) renderContact() string {
 
 	return content.String()
 }
+
+func (m *PortfolioModel) renderLive() string {
+	var content strings.Builder
+
+	content.WriteString(m.styles.SectionTitle.Render("📡 Live Demo"))
+	content.WriteString("\n\n")
+
+	// Session statistics
+	sessionDuration := time.Since(m.startTime)
+	session := []string{
+		fmt.Sprintf("⏱️  Session duration: %s", formatDuration(sessionDuration)),
+		fmt.Sprintf("📐 Terminal size:    %dx%d", m.width, m.height),
+		fmt.Sprintf("🎨 Color profile:    %s", colorProfileName(m.renderer.ColorProfile())),
+	}
+
+	content.WriteString(m.styles.LiveTitle.Render("Your Session"))
+	content.WriteString("\n")
+	content.WriteString(m.styles.StatsBox.Render(strings.Join(session, "\n")))
+	content.WriteString("\n\n")
+
+	// Server statistics
+	content.WriteString(m.styles.LiveTitle.Render("Server"))
+	content.WriteString("\n")
+	if m.stats == nil {
+		content.WriteString(m.styles.ContentText.Render("Server statistics are not available."))
+		return content.String()
+	}
+
+	serverStats := []string{
+		fmt.Sprintf("🚀 Uptime:           %s", formatDuration(m.stats.Uptime())),
+		fmt.Sprintf("👥 Connected now:    %d", m.stats.ActiveSessions()),
+		fmt.Sprintf("📈 Sessions served:  %d", m.stats.TotalSessions()),
+	}
+	content.WriteString(m.styles.StatsBox.Render(strings.Join(serverStats, "\n")))
+	content.WriteString("\n\n")
+
+	refreshed := "Refreshed live • "
+	if m.accessible {
+		refreshed = "As of "
+	}
+	content.WriteString(m.styles.LiveSubtitle.Render(refreshed + time.Now().Format("15:04:05 MST")))
+	content.WriteString("\n")
+
+	return content.String()
+}
+
+// formatDuration renders a duration as hours, minutes and seconds
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d%time.Hour) / int(time.Minute)
+	seconds := int(d%time.Minute) / int(time.Second)
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh %02dm %02ds", hours, minutes, seconds)
+	}
+	if minutes > 0 {
+		return fmt.Sprintf("%dm %02ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// colorProfileName returns a human readable name for a terminal color profile
+func colorProfileName(profile termenv.Profile) string {
+	switch profile {
+	case termenv.TrueColor:
+		return "TrueColor (24-bit)"
+	case termenv.ANSI256:
+		return "ANSI256 (8-bit)"
+	case termenv.ANSI:
+		return "ANSI (4-bit)"
+	default:
+		return "No color"
+	}
+}
+
+// markdownIndent is how far nested text fields are indented under a heading
+const markdownIndent = 4
+
+// renderMarkdownText renders a Markdown text field indented by indent columns
+// and wrapped to the remaining viewport width. The base style's margins are
+// left for the caller to apply but are accounted for when wrapping.
+func (m *PortfolioModel) renderMarkdownText(src string, indent int, base lipgloss.Style) string {
+	text := renderMarkdown(src, m.markdownWidth(indent, base), base, m.styles)
+	return indentLines(text, indent)
+}
+
+// renderMarkdownBullet renders a Markdown list item with a bullet, indented by
+// indent columns and wrapped like renderMarkdownText
+func (m *PortfolioModel) renderMarkdownBullet(src string, indent int, base lipgloss.Style) string {
+	item := renderMarkdownListItem("•", src, m.markdownWidth(indent, base), base, m.styles)
+	return indentLines(item, indent)
+}
+
+// markdownWidth returns the wrap width left after indenting and framing with
+// base, or 0 (no wrapping) before the terminal size is known
+func (m *PortfolioModel) markdownWidth(indent int, base lipgloss.Style) int {
+	if m.viewport.Width <= 0 {
+		return 0
+	}
+	return max(m.viewport.Width-indent-base.GetHorizontalFrameSize(), 20)
+}
+
+// indentLines prefixes every line of s with indent spaces
+func indentLines(s string, indent int) string {
+	if indent <= 0 {
+		return s
+	}
+
+	prefix := strings.Repeat(" ", indent)
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
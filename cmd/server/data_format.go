@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DataFormat identifies the encoding of a portfolio data file
+type DataFormat string
+
+const (
+	FormatAuto DataFormat = "" // Detect from the file extension
+	FormatJSON DataFormat = "json"
+	FormatYAML DataFormat = "yaml"
+	FormatTOML DataFormat = "toml"
+
+	// FormatMarkdown reads a directory of Markdown files with YAML front matter
+	FormatMarkdown DataFormat = "markdown"
+)
+
+// ParseDataFormat converts a user supplied format name into a DataFormat
+func ParseDataFormat(name string) (DataFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return FormatAuto, nil
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	default:
+		return FormatAuto, fmt.Errorf("unsupported data format %q (expected json, yaml, toml or markdown)", name)
+	}
+}
+
+// detectDataFormat picks a format from the file extension, defaulting to JSON.
+// Directories are read as Markdown content.
+func detectDataFormat(path string) DataFormat {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return FormatMarkdown
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// decodePortfolioData parses raw file contents in the given format. YAML and
+// TOML documents use the same field names as JSON.
+func decodePortfolioData(raw []byte, format DataFormat) (*PortfolioData, error) {
+	var (
+		portfolioData PortfolioData
+		err           error
+	)
+	switch format {
+	case FormatYAML:
+		err = decodeYAML(raw, &portfolioData, false)
+	case FormatTOML:
+		err = decodeTOML(raw, &portfolioData, false)
+	default:
+		err = json.Unmarshal(raw, &portfolioData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s data: %w", strings.ToUpper(string(format)), err)
+	}
+
+	return &portfolioData, nil
+}
+
+// yamlUnknownField matches yaml.v3's error for a key with no struct field
+var yamlUnknownField = regexp.MustCompile(`field (.*) not found in type \S+$`)
+
+// decodeYAML decodes a YAML document into v. With strict, keys v has no field
+// for are an error. Each mismatched value is reported as its own error.
+func decodeYAML(raw []byte, v any, strict bool) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(strict)
+
+	err := decoder.Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil // Empty document
+	}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		errs := make([]error, len(typeErr.Errors))
+		for i, msg := range typeErr.Errors {
+			// Name unknown keys as the other formats do, not by Go type
+			msg = yamlUnknownField.ReplaceAllString(msg, `unknown field "$1"`)
+			errs[i] = errors.New("yaml: " + msg)
+		}
+		return errors.Join(errs...)
+	}
+	return err
+}
+
+// decodeTOML decodes a TOML document into v. With strict, keys v has no field
+// for are an error.
+func decodeTOML(raw []byte, v any, strict bool) error {
+	meta, err := toml.Decode(string(raw), v)
+	if err != nil {
+		return err
+	}
+	if undecoded := meta.Undecoded(); strict && len(undecoded) > 0 {
+		return fmt.Errorf("toml: unknown field %q", undecoded[0].String())
+	}
+	return nil
+}
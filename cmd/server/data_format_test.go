@@ -0,0 +1,56 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodePortfolioData(t *testing.T) {
+	tests := []struct {
+		name   string
+		format DataFormat
+		raw    string
+	}{
+		{"json", FormatJSON, `{"personal": {"name": "Ada"}, "skills": {"2024": [{"name": "Go", "percentage": 90}]}}`},
+		{"yaml", FormatYAML, "personal:\n  name: Ada\nskills:\n  2024:\n    - name: Go\n      percentage: 90\n"},
+		{"toml", FormatTOML, "[personal]\nname = \"Ada\"\n\n[[skills.2024]]\nname = \"Go\"\npercentage = 90\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := decodePortfolioData([]byte(tt.raw), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.Personal.Name != "Ada" {
+				t.Errorf("name %q", data.Personal.Name)
+			}
+			if skills := data.Skills["2024"]; len(skills) != 1 || skills[0].Name != "Go" || skills[0].Percentage != 90 {
+				t.Errorf("skills %+v", data.Skills)
+			}
+		})
+	}
+}
+
+func TestDecodePortfolioDataErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format DataFormat
+		raw    string
+		want   string
+	}{
+		{"json", FormatJSON, `{"skills": {"Go": [{"percentage": "lots"}]}}`, "failed to parse JSON data: json: "},
+		{"yaml syntax", FormatYAML, "personal:\n  name: [Ada\n", "failed to parse YAML data: yaml: line "},
+		{"yaml type", FormatYAML, "personal:\n  name: Ada\nskills:\n  Go:\n    - percentage: lots\n", "failed to parse YAML data: yaml: line 5: "},
+		{"toml", FormatTOML, "[[skills.Go]]\npercentage = \"lots\"\n", "failed to parse TOML data: toml: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodePortfolioData([]byte(tt.raw), tt.format)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error %v, want it to start with %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,146 +1,273 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 )
 
 // Data structures for portfolio data
 type PersonalInfo struct {
-	Name     string  `json:"name"`
-	Nickname string  `json:"nickname"`
-	Title    string  `json:"title"`
-	Location string  `json:"location"`
-	Timezone string  `json:"timezone"`
-	About    About   `json:"about"`
-	Contact  Contact `json:"contact"`
+	Name     string  `json:"name" yaml:"name" toml:"name"`
+	Nickname string  `json:"nickname" yaml:"nickname" toml:"nickname"`
+	Title    string  `json:"title" yaml:"title" toml:"title"`
+	Location string  `json:"location" yaml:"location" toml:"location"`
+	Timezone string  `json:"timezone" yaml:"timezone" toml:"timezone"`
+	About    About   `json:"about" yaml:"about" toml:"about"`
+	Contact  Contact `json:"contact" yaml:"contact" toml:"contact"`
 }
 
 type About struct {
-	Intro      string   `json:"intro"`
-	WhatIDo    string   `json:"whatIDo"`
-	Background []string `json:"background"`
-	Philosophy string   `json:"philosophy"`
+	Intro      string   `json:"intro" yaml:"intro" toml:"intro"`
+	WhatIDo    string   `json:"whatIDo" yaml:"whatIDo" toml:"whatIDo"`
+	Background []string `json:"background" yaml:"background" toml:"background"`
+	Philosophy string   `json:"philosophy" yaml:"philosophy" toml:"philosophy"`
 }
 
 type Contact struct {
-	Email            string   `json:"email"`
-	GitHub           string   `json:"github"`
-	LinkedIn         string   `json:"linkedin"`
-	Portfolio        string   `json:"portfolio"`
-	PreferredContact string   `json:"preferredContact"`
-	ResponseTime     string   `json:"responseTime"`
-	AvailableFor     []string `json:"availableFor"`
-	Specializations  []string `json:"specializations"`
+	Email            string   `json:"email" yaml:"email" toml:"email"`
+	GitHub           string   `json:"github" yaml:"github" toml:"github"`
+	LinkedIn         string   `json:"linkedin" yaml:"linkedin" toml:"linkedin"`
+	Portfolio        string   `json:"portfolio" yaml:"portfolio" toml:"portfolio"`
+	PreferredContact string   `json:"preferredContact" yaml:"preferredContact" toml:"preferredContact"`
+	ResponseTime     string   `json:"responseTime" yaml:"responseTime" toml:"responseTime"`
+	AvailableFor     []string `json:"availableFor" yaml:"availableFor" toml:"availableFor"`
+	Specializations  []string `json:"specializations" yaml:"specializations" toml:"specializations"`
 }
 
 type Experience struct {
-	Title        string   `json:"title"`
-	Company      string   `json:"company"`
-	Period       string   `json:"period"`
-	Location     string   `json:"location"`
-	Type         string   `json:"type"`
-	Current      bool     `json:"current"`
-	Details      []string `json:"details"`
-	Technologies []string `json:"technologies"`
+	Title        string   `json:"title" yaml:"title" toml:"title"`
+	Company      string   `json:"company" yaml:"company" toml:"company"`
+	Period       string   `json:"period" yaml:"period" toml:"period"`
+	Location     string   `json:"location" yaml:"location" toml:"location"`
+	Type         string   `json:"type" yaml:"type" toml:"type"`
+	Current      bool     `json:"current" yaml:"current" toml:"current"`
+	Details      []string `json:"details" yaml:"details" toml:"details"`
+	Technologies []string `json:"technologies" yaml:"technologies" toml:"technologies"`
 }
 
 type Skill struct {
-	Name       string `json:"name"`
-	Percentage int    `json:"percentage"`
-	Experience string `json:"experience"`
+	Name       string `json:"name" yaml:"name" toml:"name"`
+	Percentage int    `json:"percentage" yaml:"percentage" toml:"percentage"`
+	Experience string `json:"experience" yaml:"experience" toml:"experience"`
+}
+
+type Project struct {
+	Name        string   `json:"name" yaml:"name" toml:"name"`
+	Description string   `json:"description" yaml:"description" toml:"description"`
+	RepoURL     string   `json:"repoUrl" yaml:"repoUrl" toml:"repoUrl"`
+	Stack       []string `json:"stack" yaml:"stack" toml:"stack"`
+	Status      string   `json:"status" yaml:"status" toml:"status"`
+	Highlights  []string `json:"highlights" yaml:"highlights" toml:"highlights"`
+	StartDate   string   `json:"startDate" yaml:"startDate" toml:"startDate"`
+	EndDate     string   `json:"endDate" yaml:"endDate" toml:"endDate"`
 }
 
 type AsciiArt struct {
-	Logo    string `json:"logo"`
-	Contact string `json:"contact"`
+	Logo    string `json:"logo" yaml:"logo" toml:"logo"`
+	Contact string `json:"contact" yaml:"contact" toml:"contact"`
 }
 
 type PortfolioData struct {
-	Personal    PersonalInfo       `json:"personal"`
-	Experiences []Experience       `json:"experiences"`
-	Skills      map[string][]Skill `json:"skills"`
-	TechFacts   []string           `json:"techFacts"`
-	AsciiArt    AsciiArt           `json:"asciiArt"`
+	Personal    PersonalInfo       `json:"personal" yaml:"personal" toml:"personal"`
+	Experiences []Experience       `json:"experiences" yaml:"experiences" toml:"experiences"`
+	Skills      map[string][]Skill `json:"skills" yaml:"skills" toml:"skills"`
+	Projects    []Project          `json:"projects" yaml:"projects" toml:"projects"`
+	TechFacts   []string           `json:"techFacts" yaml:"techFacts" toml:"techFacts"`
+	AsciiArt    AsciiArt           `json:"asciiArt" yaml:"asciiArt" toml:"asciiArt"`
+	Theme       string             `json:"theme,omitempty" yaml:"theme,omitempty" toml:"theme,omitempty"` // Default theme, unless the server sets one
 }
 
-// DataLoader handles loading and caching portfolio data
+// DataLoader handles loading and caching portfolio data. It is shared by every
+// session, so loaded data is published as an immutable snapshot that readers
+// can use without locking.
 type DataLoader struct {
-	data     *PortfolioData
+	data     atomic.Pointer[PortfolioData]
 	dataPath string
+	format   DataFormat
+
+	// loadMu serializes loads so an older read can never replace a newer one
+	loadMu sync.Mutex
 }
 
-// NewDataLoader creates a new data loader instance
-func NewDataLoader(dataPath string) *DataLoader {
+// NewDataLoader creates a new data loader instance. With FormatAuto the
+// format is detected from the file extension.
+func NewDataLoader(dataPath string, format DataFormat) *DataLoader {
+	if format == FormatAuto {
+		format = detectDataFormat(dataPath)
+	}
+
 	return &DataLoader{
 		dataPath: dataPath,
+		format:   format,
 	}
 }
 
-// LoadData loads portfolio data from JSON file
+// LoadData loads portfolio data from a JSON, YAML or TOML file, or from a
+// directory of Markdown files
 func (dl *DataLoader) LoadData() error {
+	dl.loadMu.Lock()
+	defer dl.loadMu.Unlock()
+
+	portfolioData, err := dl.readData()
+	if err != nil {
+		return err
+	}
+
+	dl.data.Store(portfolioData)
+	return nil
+}
+
+// readData reads and parses the data file without replacing the loaded data
+func (dl *DataLoader) readData() (*PortfolioData, error) {
 	// Get the absolute path for the data file
 	absPath, err := filepath.Abs(dl.dataPath)
 	if err != nil {
-		return fmt.Errorf("failed to get absolute path: %w", err)
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
 	// Check if file exists
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return fmt.Errorf("data file not found at: %s", absPath)
+		return nil, fmt.Errorf("data file not found at: %s", absPath)
 	}
 
-	// Read the JSON file
+	// Assemble Markdown content directories file by file
+	if dl.format == FormatMarkdown {
+		return loadMarkdownDir(absPath)
+	}
+
+	// Read the data file
 	data, err := os.ReadFile(absPath)
 	if err != nil {
-		return fmt.Errorf("failed to read data file: %w", err)
+		return nil, fmt.Errorf("failed to read data file: %w", err)
 	}
 
-	// Parse JSON
-	var portfolioData PortfolioData
-	if err := json.Unmarshal(data, &portfolioData); err != nil {
-		return fmt.Errorf("failed to parse JSON data: %w", err)
-	}
+	return decodePortfolioData(data, dl.format)
+}
 
-	dl.data = &portfolioData
-	return nil
+// Snapshot returns the currently loaded data. A snapshot is never modified
+// after it has been published, so callers must treat it as read-only.
+func (dl *DataLoader) Snapshot() *PortfolioData {
+	return dl.data.Load()
 }
 
 // GetData returns the loaded portfolio data
 func (dl *DataLoader) GetData() *PortfolioData {
-	return dl.data
+	return dl.Snapshot()
 }
 
 // IsLoaded checks if data has been loaded
 func (dl *DataLoader) IsLoaded() bool {
-	return dl.data != nil
+	return dl.Snapshot() != nil
 }
 
 // GetPersonalInfo returns personal information
 func (dl *DataLoader) GetPersonalInfo() *PersonalInfo {
-	if dl.data == nil {
+	return dl.Snapshot().GetPersonalInfo()
+}
+
+// GetExperiences returns all experiences
+func (dl *DataLoader) GetExperiences() []Experience {
+	return dl.Snapshot().GetExperiences()
+}
+
+// GetCurrentExperience returns current experience (if any)
+func (dl *DataLoader) GetCurrentExperience() *Experience {
+	return dl.Snapshot().GetCurrentExperience()
+}
+
+// GetSkills returns all skills organized by category
+func (dl *DataLoader) GetSkills() map[string][]Skill {
+	return dl.Snapshot().GetSkills()
+}
+
+// GetSkillsByCategory returns skills for a specific category
+func (dl *DataLoader) GetSkillsByCategory(category string) []Skill {
+	return dl.Snapshot().GetSkillsByCategory(category)
+}
+
+// GetProjects returns all projects
+func (dl *DataLoader) GetProjects() []Project {
+	return dl.Snapshot().GetProjects()
+}
+
+// GetTechFacts returns all tech facts
+func (dl *DataLoader) GetTechFacts() []string {
+	return dl.Snapshot().GetTechFacts()
+}
+
+// GetRandomTechFact returns a random tech fact based on index
+func (dl *DataLoader) GetRandomTechFact(index int) string {
+	return dl.Snapshot().GetRandomTechFact(index)
+}
+
+// GetAsciiArt returns ASCII art
+func (dl *DataLoader) GetAsciiArt() *AsciiArt {
+	return dl.Snapshot().GetAsciiArt()
+}
+
+// GetContact returns contact information
+func (dl *DataLoader) GetContact() *Contact {
+	return dl.Snapshot().GetContact()
+}
+
+// ReloadData reloads data from file (useful for hot-reloading during development).
+// The new data is only used if it parses and passes validation; otherwise the
+// previously loaded data is kept.
+func (dl *DataLoader) ReloadData() error {
+	dl.loadMu.Lock()
+	defer dl.loadMu.Unlock()
+
+	portfolioData, err := dl.readData()
+	if err != nil {
+		return err
+	}
+
+	if err := validatePortfolioData(portfolioData); err != nil {
+		return fmt.Errorf("data validation failed: %w", err)
+	}
+
+	dl.data.Store(portfolioData)
+	return nil
+}
+
+// ValidateData validates the loaded data and returns a *ValidationError listing
+// every error found, or nil if there are only warnings
+func (dl *DataLoader) ValidateData() error {
+	return validatePortfolioData(dl.Snapshot())
+}
+
+// Validate returns every error and warning found in the loaded data, which
+// may pick any of themes
+func (dl *DataLoader) Validate(themes []*Theme) []ValidationIssue {
+	return ValidatePortfolioData(dl.Snapshot(), themes)
+}
+
+// GetPersonalInfo returns personal information
+func (d *PortfolioData) GetPersonalInfo() *PersonalInfo {
+	if d == nil {
 		return nil
 	}
-	return &dl.data.Personal
+	return &d.Personal
 }
 
 // GetExperiences returns all experiences
-func (dl *DataLoader) GetExperiences() []Experience {
-	if dl.data == nil {
+func (d *PortfolioData) GetExperiences() []Experience {
+	if d == nil {
 		return nil
 	}
-	return dl.data.Experiences
+	return d.Experiences
 }
 
 // GetCurrentExperience returns current experience (if any)
-func (dl *DataLoader) GetCurrentExperience() *Experience {
-	if dl.data == nil {
+func (d *PortfolioData) GetCurrentExperience() *Experience {
+	if d == nil {
 		return nil
 	}
 
-	for _, exp := range dl.data.Experiences {
+	for _, exp := range d.Experiences {
 		if exp.Current {
 			return &exp
 		}
@@ -149,37 +276,45 @@ func (dl *DataLoader) GetCurrentExperience() *Experience {
 }
 
 // GetSkills returns all skills organized by category
-func (dl *DataLoader) GetSkills() map[string][]Skill {
-	if dl.data == nil {
+func (d *PortfolioData) GetSkills() map[string][]Skill {
+	if d == nil {
 		return nil
 	}
-	return dl.data.Skills
+	return d.Skills
 }
 
 // GetSkillsByCategory returns skills for a specific category
-func (dl *DataLoader) GetSkillsByCategory(category string) []Skill {
-	if dl.data == nil {
+func (d *PortfolioData) GetSkillsByCategory(category string) []Skill {
+	if d == nil {
 		return nil
 	}
 
-	skills, exists := dl.data.Skills[category]
+	skills, exists := d.Skills[category]
 	if !exists {
 		return nil
 	}
 	return skills
 }
 
+// GetProjects returns all projects
+func (d *PortfolioData) GetProjects() []Project {
+	if d == nil {
+		return nil
+	}
+	return d.Projects
+}
+
 // GetTechFacts returns all tech facts
-func (dl *DataLoader) GetTechFacts() []string {
-	if dl.data == nil {
+func (d *PortfolioData) GetTechFacts() []string {
+	if d == nil {
 		return nil
 	}
-	return dl.data.TechFacts
+	return d.TechFacts
 }
 
 // GetRandomTechFact returns a random tech fact based on index
-func (dl *DataLoader) GetRandomTechFact(index int) string {
-	facts := dl.GetTechFacts()
+func (d *PortfolioData) GetRandomTechFact(index int) string {
+	facts := d.GetTechFacts()
 	if len(facts) == 0 {
 		return "Loading awesome tech facts..."
 	}
@@ -187,50 +322,23 @@ func (dl *DataLoader) GetRandomTechFact(index int) string {
 }
 
 // GetAsciiArt returns ASCII art
-func (dl *DataLoader) GetAsciiArt() *AsciiArt {
-	if dl.data == nil {
+func (d *PortfolioData) GetAsciiArt() *AsciiArt {
+	if d == nil {
 		return nil
 	}
-	return &dl.data.AsciiArt
+	return &d.AsciiArt
 }
 
 // GetContact returns contact information
-func (dl *DataLoader) GetContact() *Contact {
-	if dl.data == nil {
+func (d *PortfolioData) GetContact() *Contact {
+	if d == nil {
 		return nil
 	}
-	return &dl.data.Personal.Contact
-}
-
-// ReloadData reloads data from file (useful for hot-reloading during development)
-func (dl *DataLoader) ReloadData() error {
-	return dl.LoadData()
+	return &d.Personal.Contact
 }
 
-// ValidateData performs basic validation on loaded data
-func (dl *DataLoader) ValidateData() error {
-	if dl.data == nil {
-		return fmt.Errorf("no data loaded")
-	}
-
-	// Validate personal info
-	if dl.data.Personal.Name == "" {
-		return fmt.Errorf("personal name is required")
-	}
-
-	if dl.data.Personal.Contact.Email == "" {
-		return fmt.Errorf("contact email is required")
-	}
-
-	// Validate experiences
-	if len(dl.data.Experiences) == 0 {
-		return fmt.Errorf("at least one experience is required")
-	}
-
-	// Validate skills
-	if len(dl.data.Skills) == 0 {
-		return fmt.Errorf("at least one skill category is required")
-	}
-
-	return nil
+// validatePortfolioData returns the error level issues of the data as an error
+func validatePortfolioData(data *PortfolioData) error {
+	// The theme is only ever a warning, so the themes don't matter here
+	return validationError(ValidatePortfolioData(data, nil))
 }
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testPortfolioJSON is a small portfolio that passes validation. The name is
+// filled in so reloads can tell versions apart.
+const testPortfolioJSON = `{
+	"personal": {
+		"name": %q,
+		"title": "Developer",
+		"location": "Earth",
+		"timezone": "UTC",
+		"about": {"intro": "Hello, I *build* things.", "background": ["One", "Two"]},
+		"contact": {"email": "me@example.com", "github": "github.com/me"}
+	},
+	"experiences": [{"title": "Dev", "company": "Acme", "period": "2020 - Present", "location": "Remote", "current": true, "details": ["Shipped **it**"]}],
+	"skills": {"Languages": [{"name": "Go", "percentage": 90, "experience": "5 years"}]},
+	"projects": [{"name": "Tool", "description": "A tool", "status": "Active"}],
+	"techFacts": ["Fact one", "Fact two"]
+}`
+
+// writeFile writes a test file, creating its directory
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestLoader loads testPortfolioJSON from a temporary file
+func newTestLoader(t *testing.T) *DataLoader {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "portfolio.json")
+	writeFile(t, path, fmt.Sprintf(testPortfolioJSON, "Ada"))
+
+	loader := NewDataLoader(path, FormatAuto)
+	if err := loader.LoadData(); err != nil {
+		t.Fatalf("LoadData: %v", err)
+	}
+	return loader
+}
+
+func TestReloadWhileRendering(t *testing.T) {
+	loader := newTestLoader(t)
+	config := &ServerConfig{DataLoader: loader, Programs: NewProgramRegistry(), Effects: true}
+
+	done := make(chan struct{})
+	var reloads sync.WaitGroup
+	reloads.Add(1)
+	go func() {
+		defer reloads.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			// Replace the file atomically so every reload reads a whole version
+			next := loader.dataPath + ".next"
+			writeFile(t, next, fmt.Sprintf(testPortfolioJSON, fmt.Sprintf("Ada %d", i)))
+			if err := os.Rename(next, loader.dataPath); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := loader.ReloadData(); err != nil {
+				t.Errorf("ReloadData: %v", err)
+				return
+			}
+		}
+	}()
+
+	var renders sync.WaitGroup
+	for range 8 {
+		renders.Add(1)
+		go func() {
+			defer renders.Done()
+			for range 5 {
+				m := NewPortfolioModel(100, 30, config)
+				m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+				for _, section := range append(navigationSections, HelpSection) {
+					m.currentSection = section
+					m.updateContent()
+					if m.View() == "" {
+						t.Error("empty view")
+					}
+				}
+			}
+		}()
+	}
+
+	renders.Wait()
+	close(done)
+	reloads.Wait()
+}
+
+func TestReloadKeepsDataOnError(t *testing.T) {
+	loader := newTestLoader(t)
+	before := loader.Snapshot()
+
+	writeFile(t, loader.dataPath, `{"personal": `)
+	if err := loader.ReloadData(); err == nil {
+		t.Fatal("ReloadData succeeded with a broken file")
+	}
+	if loader.Snapshot() != before {
+		t.Error("a failed reload replaced the data")
+	}
+}
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Markdown content directories are laid out as:
+//
+//	about.md           personal info and theme in front matter, intro text in
+//	                   the body, plus "## What I Do" and "## Philosophy" sections
+//	contact.md         contact details in front matter
+//	skills.md          skill categories in front matter
+//	facts.md           one tech fact per list item
+//	experience/*.md    one job per file, details as list items or paragraphs
+//	                   in the body
+//	projects/*.md      one project per file, description in the body
+//	ascii/logo.txt     ASCII art shown on the About page
+//	ascii/contact.txt  ASCII art shown on the Contact page
+//
+// Files in experience/ and projects/ are read in file name order, so prefix
+// them with numbers (01-current-job.md) to control the order.
+
+// loadMarkdownDir assembles portfolio data from a Markdown content directory
+func loadMarkdownDir(dir string) (*PortfolioData, error) {
+	var data PortfolioData
+
+	if err := loadMarkdownAbout(filepath.Join(dir, "about.md"), &data); err != nil {
+		return nil, err
+	}
+
+	if err := loadMarkdownFrontMatter(filepath.Join(dir, "contact.md"), &data.Personal.Contact); err != nil {
+		return nil, err
+	}
+
+	var skills struct {
+		Skills map[string][]Skill `json:"skills" yaml:"skills"`
+	}
+	if err := loadMarkdownFrontMatter(filepath.Join(dir, "skills.md"), &skills); err != nil {
+		return nil, err
+	}
+	data.Skills = skills.Skills
+
+	facts, err := loadMarkdownList(filepath.Join(dir, "facts.md"))
+	if err != nil {
+		return nil, err
+	}
+	data.TechFacts = facts
+
+	experiences, err := markdownFiles(filepath.Join(dir, "experience"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range experiences {
+		var exp Experience
+		body, err := readMarkdownFile(path, &exp)
+		if err != nil {
+			return nil, err
+		}
+		if details := markdownDetails(body); len(details) > 0 {
+			exp.Details = details
+		}
+		data.Experiences = append(data.Experiences, exp)
+	}
+
+	projects, err := markdownFiles(filepath.Join(dir, "projects"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range projects {
+		var project Project
+		body, err := readMarkdownFile(path, &project)
+		if err != nil {
+			return nil, err
+		}
+		if description := strings.TrimSpace(body); description != "" {
+			project.Description = description
+		}
+		data.Projects = append(data.Projects, project)
+	}
+
+	if data.AsciiArt.Logo, err = readOptionalFile(filepath.Join(dir, "ascii", "logo.txt")); err != nil {
+		return nil, err
+	}
+	if data.AsciiArt.Contact, err = readOptionalFile(filepath.Join(dir, "ascii", "contact.txt")); err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// loadMarkdownAbout reads personal info and the theme from the front matter
+// of about.md and the long about texts from its body
+func loadMarkdownAbout(path string, data *PortfolioData) error {
+	var frontMatter struct {
+		PersonalInfo `yaml:",inline"`
+		Background   []string `json:"background" yaml:"background"`
+		Theme        string   `json:"theme" yaml:"theme"`
+	}
+
+	body, err := readMarkdownFile(path, &frontMatter)
+	if err != nil {
+		return err
+	}
+
+	data.Theme = frontMatter.Theme
+	personal := &data.Personal
+	*personal = frontMatter.PersonalInfo
+	personal.About.Background = frontMatter.Background
+
+	intro, sections := splitMarkdownSections(body)
+	if intro != "" {
+		personal.About.Intro = intro
+	}
+
+	for heading, text := range sections {
+		switch normalizeHeading(heading) {
+		case "whatido":
+			personal.About.WhatIDo = text
+		case "philosophy", "alwayslearning":
+			personal.About.Philosophy = text
+		case "background":
+			items, err := markdownListItems(text)
+			if err != nil {
+				return fmt.Errorf("%s: background: %w", path, err)
+			}
+			personal.About.Background = items
+		default:
+			return fmt.Errorf("%s: unknown section %q (expected What I Do, Philosophy or Background)", path, heading)
+		}
+	}
+
+	return nil
+}
+
+// loadMarkdownFrontMatter decodes only the front matter of an optional file
+func loadMarkdownFrontMatter(path string, v any) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	_, err := readMarkdownFile(path, v)
+	return err
+}
+
+// loadMarkdownList returns the list items in the body of an optional file
+func loadMarkdownList(path string) ([]string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	body, err := readMarkdownFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, err := markdownListItems(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return items, nil
+}
+
+// readMarkdownFile decodes the YAML front matter of a Markdown file into v
+// (when v is not nil) and returns the remaining body
+func readMarkdownFile(path string, v any) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read data file: %w", err)
+	}
+
+	frontMatter, body := splitFrontMatter(raw)
+	if v != nil && len(bytes.TrimSpace(frontMatter)) > 0 {
+		if err := decodeYAML(frontMatter, v, false); err != nil {
+			return "", fmt.Errorf("%s: failed to parse front matter: %w", path, err)
+		}
+	}
+
+	return string(body), nil
+}
+
+// splitFrontMatter separates a leading "---" delimited YAML block from the body
+func splitFrontMatter(raw []byte) (frontMatter, body []byte) {
+	raw = bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(raw, []byte("---\n")) {
+		return nil, raw
+	}
+
+	rest := raw[len("---\n"):]
+	if bytes.HasPrefix(rest, []byte("---\n")) {
+		return nil, rest[len("---\n"):]
+	}
+
+	end := bytes.Index(rest, []byte("\n---\n"))
+	if end < 0 {
+		if bytes.HasSuffix(rest, []byte("\n---")) {
+			return rest[:len(rest)-len("---")], nil
+		}
+		return nil, raw
+	}
+
+	return rest[:end+1], rest[end+len("\n---\n"):]
+}
+
+// splitMarkdownSections splits a body on level two headings outside code
+// blocks. Text before the first heading is returned separately.
+func splitMarkdownSections(body string) (string, map[string]string) {
+	sections := make(map[string]string)
+	var intro strings.Builder
+	var current string
+	var text strings.Builder
+	var fence string // Marker of the code block being read
+
+	flush := func() {
+		if current != "" {
+			sections[current] = strings.TrimSpace(text.String())
+		}
+		text.Reset()
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		switch marker := codeFence(line); {
+		case fence == "" && marker != "":
+			fence = marker
+		case fence != "" && marker == fence:
+			fence = ""
+		}
+
+		if heading, ok := strings.CutPrefix(line, "## "); ok && fence == "" {
+			flush()
+			current = strings.TrimSpace(heading)
+			continue
+		}
+		if current == "" {
+			intro.WriteString(line + "\n")
+		} else {
+			text.WriteString(line + "\n")
+		}
+	}
+	flush()
+
+	return strings.TrimSpace(intro.String()), sections
+}
+
+// markdownListItems returns the list items of a Markdown body, with
+// continuation lines joined onto their item. Headings are skipped; other text
+// outside the list is an error rather than being dropped.
+func markdownListItems(body string) ([]string, error) {
+	var items []string
+	for _, block := range parseMarkdownBlocks(body) {
+		switch block.kind {
+		case mdList:
+			items = append(items, block.items...)
+		case mdHeading:
+		default:
+			text, _, _ := strings.Cut(block.text, "\n")
+			return nil, fmt.Errorf("text outside the list: %q", text)
+		}
+	}
+	return items, nil
+}
+
+// markdownDetails returns the list items and paragraphs of a Markdown body,
+// in order, so prose around a list is kept. Headings are skipped.
+func markdownDetails(body string) []string {
+	var details []string
+	for _, block := range parseMarkdownBlocks(body) {
+		switch block.kind {
+		case mdList:
+			details = append(details, block.items...)
+		case mdParagraph, mdCodeBlock:
+			details = append(details, block.text)
+		}
+	}
+	return details
+}
+
+// normalizeHeading lowercases a heading and drops everything but letters
+func normalizeHeading(heading string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, heading)
+}
+
+// markdownFiles lists the .md files of an optional directory in name order
+func markdownFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readOptionalFile returns the contents of a file, or "" if it doesn't exist
+func readOptionalFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read data file: %w", err)
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		frontMatter string
+		body        string
+	}{
+		{
+			name:        "front matter and body",
+			raw:         "---\nname: Ada\n---\nHello\n",
+			frontMatter: "name: Ada\n",
+			body:        "Hello\n",
+		},
+		{
+			name:        "windows line endings",
+			raw:         "---\r\nname: Ada\r\n---\r\nHello\r\n",
+			frontMatter: "name: Ada\n",
+			body:        "Hello\n",
+		},
+		{
+			name:        "front matter only",
+			raw:         "---\nname: Ada\n---",
+			frontMatter: "name: Ada\n",
+		},
+		{
+			name: "empty front matter",
+			raw:  "---\n---\nHello",
+			body: "Hello",
+		},
+		{
+			name: "no front matter",
+			raw:  "Hello\n---\nWorld",
+			body: "Hello\n---\nWorld",
+		},
+		{
+			name: "unclosed front matter",
+			raw:  "---\nname: Ada\nHello",
+			body: "---\nname: Ada\nHello",
+		},
+		{
+			name:        "rule in the body",
+			raw:         "---\nname: Ada\n---\nOne\n---\nTwo",
+			frontMatter: "name: Ada\n",
+			body:        "One\n---\nTwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontMatter, body := splitFrontMatter([]byte(tt.raw))
+			if string(frontMatter) != tt.frontMatter {
+				t.Errorf("front matter %q, want %q", frontMatter, tt.frontMatter)
+			}
+			if string(body) != tt.body {
+				t.Errorf("body %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestSplitMarkdownSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		intro    string
+		sections map[string]string
+	}{
+		{
+			name:     "intro only",
+			body:     "\nHello there.\n",
+			intro:    "Hello there.",
+			sections: map[string]string{},
+		},
+		{
+			name:  "sections",
+			body:  "Intro\n\n## What I Do\nThings.\n\n## Philosophy \nIdeas.\n### Detail\nMore.",
+			intro: "Intro",
+			sections: map[string]string{
+				"What I Do":  "Things.",
+				"Philosophy": "Ideas.\n### Detail\nMore.",
+			},
+		},
+		{
+			name:  "heading in a backtick fence",
+			body:  "## What I Do\n```md\n## Not a heading\n```\nAfter",
+			intro: "",
+			sections: map[string]string{
+				"What I Do": "```md\n## Not a heading\n```\nAfter",
+			},
+		},
+		{
+			name:  "heading in a tilde fence",
+			body:  "Intro\n~~~\n## Not a heading\n```\n## Still code\n~~~\n## Philosophy\nIdeas.",
+			intro: "Intro\n~~~\n## Not a heading\n```\n## Still code\n~~~",
+			sections: map[string]string{
+				"Philosophy": "Ideas.",
+			},
+		},
+		{
+			name:     "unclosed fence",
+			body:     "Intro\n```\n## Not a heading",
+			intro:    "Intro\n```\n## Not a heading",
+			sections: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intro, sections := splitMarkdownSections(tt.body)
+			if intro != tt.intro {
+				t.Errorf("intro %q, want %q", intro, tt.intro)
+			}
+			if !reflect.DeepEqual(sections, tt.sections) {
+				t.Errorf("sections %q, want %q", sections, tt.sections)
+			}
+		})
+	}
+}
+
+func TestMarkdownListItems(t *testing.T) {
+	body := "# Facts\n\n- One\n- Two\n  continued\n\n1. Three\n2. Four"
+	want := []string{"One", "Two continued", "Three", "Four"}
+	if got, err := markdownListItems(body); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, %v, want %q", got, err, want)
+	}
+
+	// Text outside the list is reported, not dropped
+	for _, body := range []string{"Facts:\n\n- One", "- One\n\nNot an item", "2023. Not a list\n- One"} {
+		if items, err := markdownListItems(body); err == nil {
+			t.Errorf("%q: no error, items %q", body, items)
+		}
+	}
+}
+
+func TestMarkdownDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"list", "- One\n- Two", []string{"One", "Two"}},
+		{"paragraphs", "One\nline.\n\nTwo.", []string{"One line.", "Two."}},
+		{"prose and a list", "Joined in\n2023. Built things\n- real item", []string{"Joined in 2023. Built things", "real item"}},
+		{"heading", "## Highlights\n- One", []string{"One"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownDetails(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExportFormat is an output format for static exports of the portfolio
+type ExportFormat string
+
+const (
+	ExportAuto     ExportFormat = ""
+	ExportHTML     ExportFormat = "html"
+	ExportText     ExportFormat = "text"
+	ExportMarkdown ExportFormat = "markdown"
+	ExportJSON     ExportFormat = "json"
+	ExportVCard    ExportFormat = "vcard"
+)
+
+// Line width of plain text exports
+const exportTextWidth = 80
+
+// ParseExportFormat converts a -to flag value into an ExportFormat
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return ExportAuto, nil
+	case "html", "htm":
+		return ExportHTML, nil
+	case "text", "txt":
+		return ExportText, nil
+	case "markdown", "md":
+		return ExportMarkdown, nil
+	case "json":
+		return ExportJSON, nil
+	case "vcard", "vcf":
+		return ExportVCard, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (expected html, text, markdown, json or vcard)", name)
+	}
+}
+
+// DetectExportFormat picks an export format from an output file extension,
+// defaulting to plain text
+func DetectExportFormat(path string) ExportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return ExportHTML
+	case ".md", ".markdown":
+		return ExportMarkdown
+	case ".json":
+		return ExportJSON
+	case ".vcf", ".vcard":
+		return ExportVCard
+	default:
+		return ExportText
+	}
+}
+
+// Export writes the portfolio as a standalone HTML page, a plain text résumé,
+// a Markdown document, JSON data or a vCard contact card. HTML pages are drawn
+// in theme, or the theme data picks when theme is nil (see ExportTheme).
+func Export(w io.Writer, data *PortfolioData, format ExportFormat, theme *Theme) error {
+	if data == nil {
+		return fmt.Errorf("no portfolio data to export")
+	}
+
+	switch format {
+	case ExportHTML:
+		if theme == nil {
+			theme, _ = ExportTheme("", builtinThemes, data)
+		}
+		return exportHTML(w, data, theme)
+	case ExportMarkdown:
+		_, err := io.WriteString(w, exportMarkdown(data))
+		return err
+	case ExportJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(data)
+	case ExportVCard:
+		_, err := io.WriteString(w, exportVCard(data))
+		return err
+	case ExportText, ExportAuto:
+		_, err := io.WriteString(w, exportText(data))
+		return err
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// exportText renders the portfolio as a plain text résumé
+func exportText(data *PortfolioData) string {
+	var b strings.Builder
+	personal := data.Personal
+	contact := personal.Contact
+
+	b.WriteString(personal.Name + "\n")
+	if personal.Title != "" {
+		b.WriteString(personal.Title + "\n")
+	}
+	if line := joinNonEmpty(" · ", personal.Location, personal.Timezone); line != "" {
+		b.WriteString(line + "\n")
+	}
+	if line := joinNonEmpty(" · ", contact.Email, contact.GitHub, contact.LinkedIn, contact.Portfolio); line != "" {
+		b.WriteString(line + "\n")
+	}
+
+	// About
+	about := personal.About
+	textSection(&b, "About")
+	if about.Intro != "" {
+		b.WriteString(plainMarkdown(about.Intro, exportTextWidth) + "\n\n")
+	}
+	if about.WhatIDo != "" {
+		b.WriteString("What I Do\n")
+		b.WriteString(plainMarkdown(about.WhatIDo, exportTextWidth) + "\n\n")
+	}
+	if len(about.Background) > 0 {
+		b.WriteString("Background\n")
+		for _, item := range about.Background {
+			b.WriteString(plainListItem("-", plainMarkdownInline(item), exportTextWidth) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if about.Philosophy != "" {
+		b.WriteString("Always Learning\n")
+		b.WriteString(plainMarkdown(about.Philosophy, exportTextWidth) + "\n")
+	}
+
+	// Experience
+	if len(data.Experiences) > 0 {
+		textSection(&b, "Experience")
+		for i, exp := range data.Experiences {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(fmt.Sprintf("%s @ %s\n", exp.Title, exp.Company))
+			if meta := joinNonEmpty(" · ", exp.Period, exp.Location, currentLabel(exp.Current)); meta != "" {
+				b.WriteString(meta + "\n")
+			}
+			for _, detail := range exp.Details {
+				b.WriteString(indentLines(plainListItem("-", plainMarkdownInline(detail), exportTextWidth-2), 2) + "\n")
+			}
+			if len(exp.Technologies) > 0 {
+				b.WriteString("  Tech: " + strings.Join(exp.Technologies, ", ") + "\n")
+			}
+		}
+	}
+
+	// Skills
+	if len(data.Skills) > 0 {
+		textSection(&b, "Skills")
+		for i, category := range sortedSkillCategories(data.Skills) {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(category + "\n")
+			for _, skill := range data.Skills[category] {
+				line := fmt.Sprintf("  %-*s %3d%%", skillNameWidth, skill.Name, skill.Percentage)
+				if skill.Experience != "" {
+					line += " (" + skill.Experience + ")"
+				}
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+
+	// Projects
+	if len(data.Projects) > 0 {
+		textSection(&b, "Projects")
+		for i, project := range data.Projects {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(project.Name + "\n")
+			if meta := joinNonEmpty(" · ", project.Status, projectPeriod(project)); meta != "" {
+				b.WriteString(meta + "\n")
+			}
+			b.WriteString(plainMarkdown(project.Description, exportTextWidth) + "\n")
+			for _, highlight := range project.Highlights {
+				b.WriteString(indentLines(plainListItem("-", plainMarkdownInline(highlight), exportTextWidth-2), 2) + "\n")
+			}
+			if len(project.Stack) > 0 {
+				b.WriteString("  Stack: " + strings.Join(project.Stack, ", ") + "\n")
+			}
+			if project.RepoURL != "" {
+				b.WriteString("  Repo:  " + project.RepoURL + "\n")
+			}
+		}
+	}
+
+	// Contact
+	textSection(&b, "Contact")
+	for _, field := range contactFields(contact) {
+		b.WriteString(fmt.Sprintf("%-10s %s\n", field.Label+":", field.Value))
+	}
+	if contact.PreferredContact != "" {
+		b.WriteString("Preferred contact method: " + contact.PreferredContact + "\n")
+	}
+	if contact.ResponseTime != "" {
+		b.WriteString("Response time: " + contact.ResponseTime + "\n")
+	}
+	if len(contact.AvailableFor) > 0 {
+		b.WriteString("\nAvailable for\n")
+		for _, item := range contact.AvailableFor {
+			b.WriteString(plainListItem("-", plainMarkdownInline(item), exportTextWidth) + "\n")
+		}
+	}
+	if len(contact.Specializations) > 0 {
+		b.WriteString("\nSpecializations\n")
+		for _, item := range contact.Specializations {
+			b.WriteString(plainListItem("-", plainMarkdownInline(item), exportTextWidth) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// textSection writes an underlined section heading
+func textSection(b *strings.Builder, title string) {
+	b.WriteString("\n" + strings.ToUpper(title) + "\n")
+	b.WriteString(strings.Repeat("=", len(title)) + "\n\n")
+}
+
+// exportMarkdown renders the portfolio as a Markdown document. Text fields are
+// already Markdown and are copied as they are.
+func exportMarkdown(data *PortfolioData) string {
+	var b strings.Builder
+	personal := data.Personal
+	contact := personal.Contact
+
+	b.WriteString("# " + personal.Name + "\n\n")
+	if personal.Title != "" {
+		b.WriteString("**" + personal.Title + "**")
+		if personal.Location != "" {
+			b.WriteString(" · " + personal.Location)
+		}
+		b.WriteString("\n\n")
+	}
+
+	// About
+	about := personal.About
+	b.WriteString("## About\n\n")
+	if about.Intro != "" {
+		b.WriteString(about.Intro + "\n\n")
+	}
+	if about.WhatIDo != "" {
+		b.WriteString("### What I Do\n\n" + about.WhatIDo + "\n\n")
+	}
+	if len(about.Background) > 0 {
+		b.WriteString("### Background\n\n")
+		for _, item := range about.Background {
+			b.WriteString("- " + item + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if about.Philosophy != "" {
+		b.WriteString("### Always Learning\n\n" + about.Philosophy + "\n\n")
+	}
+
+	// Experience
+	if len(data.Experiences) > 0 {
+		b.WriteString("## Experience\n\n")
+		for _, exp := range data.Experiences {
+			b.WriteString(fmt.Sprintf("### %s @ %s\n\n", exp.Title, exp.Company))
+			if meta := joinNonEmpty(" · ", exp.Period, exp.Location, currentLabel(exp.Current)); meta != "" {
+				b.WriteString("*" + meta + "*\n\n")
+			}
+			for _, detail := range exp.Details {
+				b.WriteString("- " + detail + "\n")
+			}
+			if len(exp.Details) > 0 {
+				b.WriteString("\n")
+			}
+			if len(exp.Technologies) > 0 {
+				b.WriteString("**Tech:** " + strings.Join(exp.Technologies, ", ") + "\n\n")
+			}
+		}
+	}
+
+	// Skills
+	if len(data.Skills) > 0 {
+		b.WriteString("## Skills\n\n")
+		for _, category := range sortedSkillCategories(data.Skills) {
+			b.WriteString("### " + category + "\n\n")
+			b.WriteString("| Skill | Level | Experience |\n")
+			b.WriteString("| --- | ---: | --- |\n")
+			for _, skill := range data.Skills[category] {
+				b.WriteString(fmt.Sprintf("| %s | %d%% | %s |\n",
+					markdownTableCell(skill.Name), skill.Percentage, markdownTableCell(skill.Experience)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Projects
+	if len(data.Projects) > 0 {
+		b.WriteString("## Projects\n\n")
+		for _, project := range data.Projects {
+			b.WriteString("### " + project.Name + "\n\n")
+			if meta := joinNonEmpty(" · ", project.Status, projectPeriod(project)); meta != "" {
+				b.WriteString("*" + meta + "*\n\n")
+			}
+			b.WriteString(project.Description + "\n\n")
+			if len(project.Highlights) > 0 {
+				b.WriteString("**Highlights:**\n\n")
+				for _, highlight := range project.Highlights {
+					b.WriteString("- " + highlight + "\n")
+				}
+				b.WriteString("\n")
+			}
+			if len(project.Stack) > 0 {
+				b.WriteString("**Stack:** " + strings.Join(project.Stack, ", ") + "\n\n")
+			}
+			if project.RepoURL != "" {
+				b.WriteString("**Repo:** " + markdownLink(project.RepoURL, project.RepoURL) + "\n\n")
+			}
+		}
+	}
+
+	// Contact
+	b.WriteString("## Contact\n\n")
+	for _, field := range contactFields(contact) {
+		b.WriteString("- **" + field.Label + ":** " + markdownLink(field.Value, field.Href) + "\n")
+	}
+	if contact.PreferredContact != "" {
+		b.WriteString("- **Preferred contact method:** " + contact.PreferredContact + "\n")
+	}
+	if contact.ResponseTime != "" {
+		b.WriteString("- **Response time:** " + contact.ResponseTime + "\n")
+	}
+	if len(contact.AvailableFor) > 0 {
+		b.WriteString("\n### Available For\n\n")
+		for _, item := range contact.AvailableFor {
+			b.WriteString("- " + item + "\n")
+		}
+	}
+	if len(contact.Specializations) > 0 {
+		b.WriteString("\n### Specializations\n\n")
+		for _, item := range contact.Specializations {
+			b.WriteString("- " + item + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// exportVCard renders the contact details as a vCard 3.0 card
+func exportVCard(data *PortfolioData) string {
+	personal := data.Personal
+	contact := personal.Contact
+
+	lines := []string{"BEGIN:VCARD", "VERSION:3.0", "FN:" + vCardEscape(personal.Name)}
+
+	// Structured name: family name last, everything before it as given names
+	names := strings.Fields(personal.Name)
+	if len(names) > 1 {
+		lines = append(lines, "N:"+vCardEscape(names[len(names)-1])+";"+vCardEscape(strings.Join(names[:len(names)-1], " "))+";;;")
+	} else {
+		lines = append(lines, "N:"+vCardEscape(personal.Name)+";;;;")
+	}
+
+	if personal.Nickname != "" {
+		lines = append(lines, "NICKNAME:"+vCardEscape(personal.Nickname))
+	}
+	if personal.Title != "" {
+		lines = append(lines, "TITLE:"+vCardEscape(personal.Title))
+	}
+	if current := data.GetCurrentExperience(); current != nil && current.Company != "" {
+		lines = append(lines, "ORG:"+vCardEscape(current.Company))
+	}
+	if contact.Email != "" {
+		lines = append(lines, "EMAIL;TYPE=INTERNET:"+vCardEscape(contact.Email))
+	}
+	for _, field := range contactFields(contact) {
+		if href := linkHref(field.Href); href != "" && field.Label != "Email" {
+			lines = append(lines, "URL;TYPE="+strings.ToLower(field.Label)+":"+vCardEscape(href))
+		}
+	}
+	if personal.Location != "" {
+		lines = append(lines, "ADR;TYPE=WORK:;;;"+vCardEscape(personal.Location)+";;;")
+	}
+	if personal.About.Intro != "" {
+		lines = append(lines, "NOTE:"+vCardEscape(plainMarkdownInline(personal.About.Intro)))
+	}
+	lines = append(lines, "END:VCARD")
+
+	// vCard lines end in CRLF
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+// vCardEscape escapes text for a vCard property value
+func vCardEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// markdownLink formats a link, falling back to plain text for unsafe targets
+func markdownLink(text, target string) string {
+	href := linkHref(target)
+	if href == "" {
+		return text
+	}
+	return "[" + text + "](" + href + ")"
+}
+
+// markdownTableCell escapes the characters that would break a table row
+func markdownTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// contactField is a labelled contact link shared by the export formats
+type contactField struct {
+	Label string
+	Value string
+	Href  string
+}
+
+// contactFields returns the contact links that are set
+func contactFields(contact Contact) []contactField {
+	var fields []contactField
+	if contact.Email != "" {
+		fields = append(fields, contactField{"Email", contact.Email, "mailto:" + contact.Email})
+	}
+	for _, field := range []contactField{
+		{"GitHub", contact.GitHub, contact.GitHub},
+		{"LinkedIn", contact.LinkedIn, contact.LinkedIn},
+		{"Portfolio", contact.Portfolio, contact.Portfolio},
+	} {
+		if field.Value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// sortedSkillCategories returns the skill categories in a stable order
+func sortedSkillCategories(skills map[string][]Skill) []string {
+	categories := make([]string, 0, len(skills))
+	for category := range skills {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+func currentLabel(current bool) string {
+	if current {
+		return "Current"
+	}
+	return ""
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
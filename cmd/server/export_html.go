@@ -0,0 +1,259 @@
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlPage is the data passed to exportTemplate
+type htmlPage struct {
+	*PortfolioData
+	Contacts   []contactField
+	Categories []string
+	Colors     Palette // The theme's palette as #rrggbb (see webPalette)
+}
+
+var exportTemplate = template.Must(template.New("portfolio").Funcs(template.FuncMap{
+	"markdown": func(src string) template.HTML {
+		return template.HTML(htmlMarkdown(src))
+	},
+	"inline": func(src string) template.HTML {
+		return template.HTML(htmlMarkdownInline(src))
+	},
+	"href":   linkHref,
+	"period": projectPeriod,
+}).Parse(exportHTMLTemplate))
+
+// ExportTheme returns the theme an HTML export is drawn in: the named one,
+// else the one data picks, else the default theme. themes are the themes to
+// choose from (see AvailableThemes); an unknown name is an error.
+func ExportTheme(name string, themes []*Theme, data *PortfolioData) (*Theme, error) {
+	if _, ok := findTheme(themes, name); name != "" && !ok {
+		return nil, fmt.Errorf("unknown theme %q (expected %s)", name, strings.Join(themeNames(themes), ", "))
+	}
+	config := &ServerConfig{Theme: name, Themes: themes}
+	return config.defaultTheme(data), nil
+}
+
+// exportHTML renders the portfolio as a standalone HTML page in the colors of
+// theme, matching the terminal
+func exportHTML(w io.Writer, data *PortfolioData, theme *Theme) error {
+	return exportTemplate.Execute(w, htmlPage{
+		PortfolioData: data,
+		Contacts:      contactFields(data.Personal.Contact),
+		Categories:    sortedSkillCategories(data.Skills),
+		Colors:        webPalette(theme.Palette),
+	})
+}
+
+const exportHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Personal.Name}}{{with .Personal.Title}} · {{.}}{{end}}</title>
+<style>
+  /* The theme's palette, matching the terminal styles */
+  :root {
+    --base: {{.Colors.Base}}; --mantle: {{.Colors.Mantle}}; --surface0: {{.Colors.Surface0}}; --surface1: {{.Colors.Surface1}};
+    --text: {{.Colors.Text}}; --subtext1: {{.Colors.Subtext1}}; --subtext0: {{.Colors.Subtext0}}; --overlay1: {{.Colors.Overlay1}};
+    --lavender: {{.Colors.Lavender}}; --blue: {{.Colors.Blue}}; --sapphire: {{.Colors.Sapphire}}; --sky: {{.Colors.Sky}};
+    --teal: {{.Colors.Teal}}; --green: {{.Colors.Green}}; --yellow: {{.Colors.Yellow}}; --peach: {{.Colors.Peach}}; --mauve: {{.Colors.Mauve}};
+  }
+  * { box-sizing: border-box; }
+  body {
+    margin: 0; background: var(--base); color: var(--text);
+    font: 16px/1.6 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace;
+  }
+  main { max-width: 52rem; margin: 0 auto; padding: 2rem 1.5rem 4rem; }
+  header {
+    text-align: center; border: 2px solid var(--lavender); border-radius: 12px;
+    padding: 1.5rem; margin-bottom: 2rem;
+  }
+  header h1 { margin: 0; font-size: 1.6rem; }
+  header p { margin: .25rem 0 0; color: var(--subtext1); font-style: italic; }
+  h2 {
+    color: var(--mauve); border-bottom: 1px solid var(--mauve);
+    padding-bottom: .5rem; margin-top: 3rem;
+  }
+  h3 { margin: 1.5rem 0 .25rem; }
+  h4, h5, h6 { color: var(--mauve); margin: 1rem 0 .25rem; }
+  a { color: var(--blue); }
+  strong { font-weight: bold; }
+  code { color: var(--peach); background: var(--surface0); padding: 0 .25em; border-radius: 4px; }
+  pre { background: var(--mantle); padding: .75rem 1rem; border-radius: 8px; overflow-x: auto; }
+  pre code { color: var(--text); background: none; padding: 0; }
+  pre.ascii { color: var(--lavender); background: none; text-align: center; }
+  ul { padding-left: 1.5rem; }
+  li::marker { color: var(--lavender); }
+  .meta { color: var(--subtext0); font-style: italic; margin: 0 0 .75rem; }
+  .label { color: var(--sky); font-weight: bold; }
+  .experience h3 { color: var(--blue); }
+  .project h3 { color: var(--peach); }
+  .project .description { color: var(--subtext1); font-style: italic; }
+  .category {
+    color: var(--teal); border-bottom: 1px solid var(--teal);
+    padding-bottom: .25rem; margin-top: 2rem;
+  }
+  .skill { display: grid; grid-template-columns: 12rem 1fr 3.5rem; gap: .75rem; align-items: center; }
+  .skill .bar { height: .75rem; background: var(--surface0); border-radius: 4px; overflow: hidden; }
+  .skill .bar span { display: block; height: 100%; background: var(--green); }
+  .skill .level { text-align: right; }
+  .skill .years { grid-column: 1 / -1; color: var(--overlay1); font-size: .85rem; margin-top: -.5rem; }
+  .contact dt { color: var(--sky); font-weight: bold; float: left; width: 7rem; }
+  .contact dd { margin: 0 0 .25rem 7rem; }
+</style>
+</head>
+<body>
+<main>
+{{with .AsciiArt.Logo}}<pre class="ascii">{{.}}</pre>
+{{end -}}
+<header>
+  <h1>{{.Personal.Name}}</h1>
+  {{- with .Personal.Title}}
+  <p>{{.}}</p>
+  {{- end}}
+  {{- with .Personal.Location}}
+  <p>{{.}}</p>
+  {{- end}}
+</header>
+
+{{with .Personal.About -}}
+<section id="about">
+  <h2>👋 About Me</h2>
+  {{markdown .Intro}}
+  {{- if .WhatIDo}}
+  <h3>🎯 What I Do</h3>
+  {{markdown .WhatIDo}}
+  {{- end}}
+  {{- if .Background}}
+  <h3>💻 Background</h3>
+  <ul>
+    {{- range .Background}}
+    <li>{{inline .}}</li>
+    {{- end}}
+  </ul>
+  {{- end}}
+  {{- if .Philosophy}}
+  <h3>🌱 Always Learning</h3>
+  {{markdown .Philosophy}}
+  {{- end}}
+</section>
+{{- end}}
+
+{{if .Experiences -}}
+<section id="experience">
+  <h2>💼 Professional Experience</h2>
+  {{- range .Experiences}}
+  <article class="experience">
+    <h3>{{.Title}} @ {{.Company}}</h3>
+    <p class="meta">📅 {{.Period}}{{with .Location}} • 📍 {{.}}{{end}}{{if .Current}} • 🟢 Current{{end}}</p>
+    {{- if .Details}}
+    <ul>
+      {{- range .Details}}
+      <li>{{inline .}}</li>
+      {{- end}}
+    </ul>
+    {{- end}}
+    {{- if .Technologies}}
+    <p><span class="label">Tech:</span> {{range $i, $t := .Technologies}}{{if $i}}, {{end}}{{$t}}{{end}}</p>
+    {{- end}}
+  </article>
+  {{- end}}
+</section>
+{{- end}}
+
+{{if .Categories -}}
+<section id="skills">
+  <h2>🛠️ Technical Skills</h2>
+  {{- range $category := .Categories}}
+  <h3 class="category">{{$category}}</h3>
+  {{- range index $.Skills $category}}
+  <div class="skill">
+    <span>{{.Name}}</span>
+    <div class="bar"><span style="width: {{.Percentage}}%"></span></div>
+    <span class="level">{{.Percentage}}%</span>
+    {{- with .Experience}}
+    <span class="years">{{.}}</span>
+    {{- end}}
+  </div>
+  {{- end}}
+  {{- end}}
+</section>
+{{- end}}
+
+{{if .Projects -}}
+<section id="projects">
+  <h2>📦 Projects</h2>
+  {{- range .Projects}}
+  <article class="project">
+    <h3>{{.Name}}</h3>
+    {{- if or .Status (period .)}}
+    <p class="meta">{{with .Status}}🏷️ {{.}}{{end}}{{if and .Status (period .)}} • {{end}}{{with period .}}📅 {{.}}{{end}}</p>
+    {{- end}}
+    <div class="description">{{markdown .Description}}</div>
+    {{- if .Highlights}}
+    <p class="label">Highlights:</p>
+    <ul>
+      {{- range .Highlights}}
+      <li>{{inline .}}</li>
+      {{- end}}
+    </ul>
+    {{- end}}
+    {{- if .Stack}}
+    <p><span class="label">Stack:</span> {{range $i, $s := .Stack}}{{if $i}}, {{end}}{{$s}}{{end}}</p>
+    {{- end}}
+    {{- with .RepoURL}}
+    <p><span class="label">Repo:</span> {{with href .}}<a href="{{.}}">{{end}}{{.}}{{if href .}}</a>{{end}}</p>
+    {{- end}}
+  </article>
+  {{- end}}
+</section>
+{{- end}}
+
+<section id="contact" class="contact">
+  <h2>📞 Get In Touch</h2>
+  <dl>
+    {{- range .Contacts}}
+    <dt>{{.Label}}</dt>
+    <dd>{{with href .Href}}<a href="{{.}}">{{end}}{{.Value}}{{if href .Href}}</a>{{end}}</dd>
+    {{- end}}
+    {{- with .Personal.Timezone}}
+    <dt>Timezone</dt>
+    <dd>{{.}}</dd>
+    {{- end}}
+  </dl>
+  {{- with .Personal.Contact}}
+  {{- if .PreferredContact}}
+  <p><span class="label">Preferred contact method:</span> {{.PreferredContact}}</p>
+  {{- end}}
+  {{- if .ResponseTime}}
+  <p><span class="label">Response time:</span> {{.ResponseTime}}</p>
+  {{- end}}
+  {{- if .AvailableFor}}
+  <h3>Feel free to reach out for</h3>
+  <ul>
+    {{- range .AvailableFor}}
+    <li>{{inline .}}</li>
+    {{- end}}
+  </ul>
+  {{- end}}
+  {{- if .Specializations}}
+  <h3>🚀 Specializations</h3>
+  <ul>
+    {{- range .Specializations}}
+    <li>{{inline .}}</li>
+    {{- end}}
+  </ul>
+  {{- end}}
+  {{- end}}
+</section>
+{{with .AsciiArt.Contact}}
+<pre class="ascii">{{.}}</pre>
+{{- end}}
+</main>
+</body>
+</html>
+`
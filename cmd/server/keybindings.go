@@ -3,14 +3,17 @@ package server
 import "github.com/charmbracelet/bubbles/key"
 
 type KeyMap struct {
-	Quit     key.Binding
-	Help     key.Binding
-	Next     key.Binding
-	Prev     key.Binding
-	Tab      key.Binding
-	ShiftTab key.Binding
-	Up       key.Binding
-	Down     key.Binding
+	Quit       key.Binding
+	Help       key.Binding
+	Next       key.Binding
+	Prev       key.Binding
+	Tab        key.Binding
+	ShiftTab   key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Theme      key.Binding
+	Background key.Binding
+	Accessible key.Binding
 }
 
 func DefaultKeyMap() KeyMap {
@@ -47,5 +50,17 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("down", "j"),
 			key.WithHelp("↓/j", "scroll down"),
 		),
+		Theme: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "next theme"),
+		),
+		Background: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "light/dark"),
+		),
+		Accessible: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "accessible mode"),
+		),
 	}
 }
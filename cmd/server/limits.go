@@ -0,0 +1,140 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// Number of client addresses tracked by the rate limiter
+const rateLimiterEntries = 4096
+
+// How long the goodbye screen is shown before a timed out session closes
+const goodbyeDelay = 3 * time.Second
+
+var (
+	ErrServerFull  = errors.New("the server is at capacity right now, please try again in a few minutes")
+	ErrRateLimited = errors.New("too many connections from your address, please try again in a minute")
+)
+
+// SessionLimits protects a publicly exposed server. A zero value disables the
+// corresponding limit.
+type SessionLimits struct {
+	MaxSessions   int           // Concurrent sessions
+	RatePerMinute int           // New sessions per client address per minute
+	MaxDuration   time.Duration // Length of a single session
+	IdleTimeout   time.Duration // Time without key or mouse input
+}
+
+// SessionLimiter enforces the session count and per address rate limits.
+// Duration and idle limits are enforced by each session's model, so it can
+// say goodbye before disconnecting.
+type SessionLimiter struct {
+	limits SessionLimits
+	active atomic.Int64
+
+	mu    sync.Mutex
+	rates *lru.Cache[string, *rate.Limiter]
+}
+
+// NewSessionLimiter creates a limiter for the given limits
+func NewSessionLimiter(limits SessionLimits) *SessionLimiter {
+	rates, _ := lru.New[string, *rate.Limiter](rateLimiterEntries)
+	return &SessionLimiter{
+		limits: limits,
+		rates:  rates,
+	}
+}
+
+// Limits returns the configured limits
+func (l *SessionLimiter) Limits() SessionLimits {
+	if l == nil {
+		return SessionLimits{}
+	}
+	return l.limits
+}
+
+// Acquire reserves a session slot for a client address. Every successful
+// call must be paired with Release.
+func (l *SessionLimiter) Acquire(addr string) error {
+	if l == nil {
+		return nil
+	}
+
+	if !l.allow(addr) {
+		return ErrRateLimited
+	}
+
+	active := l.active.Add(1)
+	if l.limits.MaxSessions > 0 && active > int64(l.limits.MaxSessions) {
+		l.active.Add(-1)
+		return ErrServerFull
+	}
+	return nil
+}
+
+// Release frees a session slot reserved by Acquire
+func (l *SessionLimiter) Release() {
+	if l == nil {
+		return
+	}
+	l.active.Add(-1)
+}
+
+// allow reports whether a client address is within its rate limit
+func (l *SessionLimiter) allow(addr string) bool {
+	if l.limits.RatePerMinute <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.rates.Get(addr)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(l.limits.RatePerMinute)), l.limits.RatePerMinute)
+		l.rates.Add(addr, limiter)
+	}
+	return limiter.Allow()
+}
+
+// Middleware turns away SSH sessions over the limits and closes sessions
+// that outlive the maximum duration. The model normally ends them first,
+// after showing a goodbye screen; this is the backstop for sessions whose
+// program is stuck.
+func (l *SessionLimiter) Middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			if err := l.Acquire(remoteIP(s.RemoteAddr())); err != nil {
+				sessionLogger(s.Context()).Warn("Session rejected", "err", err)
+				wish.Fatalln(s, "Sorry, "+err.Error()+".")
+				return
+			}
+			defer l.Release()
+
+			if l.limits.MaxDuration > 0 {
+				timer := time.AfterFunc(l.limits.MaxDuration+2*goodbyeDelay, func() {
+					_ = s.Close()
+				})
+				defer timer.Stop()
+			}
+
+			next(s)
+		}
+	}
+}
+
+// remoteIP returns the IP of a client address, without the port
+func remoteIP(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP.String()
+	}
+	return addr.String()
+}
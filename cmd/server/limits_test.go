@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSessionLimiterMaxSessions(t *testing.T) {
+	limiter := NewSessionLimiter(SessionLimits{MaxSessions: 2})
+
+	for _, addr := range []string{"192.0.2.1", "192.0.2.2"} {
+		if err := limiter.Acquire(addr); err != nil {
+			t.Fatalf("%s: %v", addr, err)
+		}
+	}
+	if err := limiter.Acquire("192.0.2.3"); !errors.Is(err, ErrServerFull) {
+		t.Fatalf("third session got %v, want ErrServerFull", err)
+	}
+
+	// A rejected session doesn't hold on to a slot, a released one frees it
+	limiter.Release()
+	if err := limiter.Acquire("192.0.2.3"); err != nil {
+		t.Fatalf("after release: %v", err)
+	}
+	if err := limiter.Acquire("192.0.2.4"); !errors.Is(err, ErrServerFull) {
+		t.Fatalf("over the cap again got %v, want ErrServerFull", err)
+	}
+}
+
+func TestSessionLimiterRateLimit(t *testing.T) {
+	limiter := NewSessionLimiter(SessionLimits{RatePerMinute: 3})
+
+	// The whole minute's allowance can be used at once
+	for i := range 3 {
+		if err := limiter.Acquire("192.0.2.1"); err != nil {
+			t.Fatalf("session %d: %v", i+1, err)
+		}
+		limiter.Release()
+	}
+	if err := limiter.Acquire("192.0.2.1"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("fourth session got %v, want ErrRateLimited", err)
+	}
+
+	// Other addresses have their own allowance
+	if err := limiter.Acquire("192.0.2.2"); err != nil {
+		t.Fatalf("other address: %v", err)
+	}
+}
+
+func TestSessionLimiterRateLimitBeforeCap(t *testing.T) {
+	limiter := NewSessionLimiter(SessionLimits{MaxSessions: 1, RatePerMinute: 1})
+
+	if err := limiter.Acquire("192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+	// A rate limited address doesn't take a slot, even briefly
+	if err := limiter.Acquire("192.0.2.1"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got %v, want ErrRateLimited", err)
+	}
+	limiter.Release()
+	if err := limiter.Acquire("192.0.2.2"); err != nil {
+		t.Fatalf("slot wasn't freed: %v", err)
+	}
+}
+
+func TestSessionLimiterDisabled(t *testing.T) {
+	for name, limiter := range map[string]*SessionLimiter{
+		"nil":  nil,
+		"zero": NewSessionLimiter(SessionLimits{}),
+	} {
+		for i := range 100 {
+			if err := limiter.Acquire("192.0.2.1"); err != nil {
+				t.Fatalf("%s limiter, session %d: %v", name, i+1, err)
+			}
+		}
+		limiter.Release()
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/term"
+)
+
+// Terminal size used when the real size can't be determined
+const (
+	fallbackWidth  = 80
+	fallbackHeight = 24
+)
+
+// RunLocal runs the portfolio directly in the current terminal, without an
+// SSH server. Useful for previewing content while editing the data file.
+// userThemes are the themes loaded from cfg.Themes (see LoadThemes).
+func RunLocal(cfg Config, userThemes []*Theme) error {
+	if err := cfg.Validate(userThemes); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	dataFormat, _ := ParseDataFormat(cfg.DataFormat)
+	sections, _ := cfg.sections()
+	watchInterval := time.Duration(cfg.Watch)
+	themes := AvailableThemes(userThemes)
+
+	dataLoader, err := loadPortfolioData(cfg.Data, dataFormat, themes)
+	if err != nil {
+		return err
+	}
+
+	config := &ServerConfig{
+		Sections:   sections,
+		Effects:    cfg.Effects,
+		Accessible: envAccessible(os.Environ(), cfg.Accessible),
+		Theme:      cfg.Theme,
+		Themes:     themes,
+		DataLoader: dataLoader,
+		Programs:   NewProgramRegistry(),
+	}
+
+	width, height, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		width, height = fallbackWidth, fallbackHeight
+	}
+
+	// Log output would draw over the TUI, so silence it while it runs
+	logger := log.Default()
+	log.SetDefault(log.New(io.Discard))
+	defer log.SetDefault(logger)
+
+	// The local terminal can be asked for its background before the program
+	// starts reading from it
+	model := NewPortfolioModel(width, height, config)
+	if term.IsTerminal(os.Stdout.Fd()) {
+		model.setBackground(lipgloss.HasDarkBackground())
+	}
+
+	p := tea.NewProgram(model,
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+	config.Programs.Register(p)
+	defer config.Programs.Unregister(p)
+
+	if watchInterval > 0 {
+		watcher := NewDataWatcher(dataLoader, config.Programs, nil, watchInterval)
+		watcher.Start()
+		defer watcher.Stop()
+	}
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to run portfolio: %w", err)
+	}
+	return nil
+}
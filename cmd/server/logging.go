@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+// contextKey is the type of the values this package stores in session contexts
+type contextKey struct{ name string }
+
+var (
+	sessionIDContextKey     = &contextKey{"session-id"}
+	sessionLoggerContextKey = &contextKey{"session-logger"}
+)
+
+// NewLogger creates a logger writing to w. level is one of debug, info, warn
+// or error; format is text or json.
+func NewLogger(w io.Writer, level, format string) (*log.Logger, error) {
+	lvl, err := log.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q (want debug, info, warn or error)", level)
+	}
+
+	options := log.Options{
+		Level:           lvl,
+		ReportTimestamp: true,
+	}
+	switch strings.ToLower(format) {
+	case "", "text":
+		options.Formatter = log.TextFormatter
+	case "json":
+		options.Formatter = log.JSONFormatter
+		options.TimeFormat = time.RFC3339Nano
+	default:
+		return nil, fmt.Errorf("invalid log format %q (want text or json)", format)
+	}
+
+	return log.NewWithOptions(w, options), nil
+}
+
+// LoggingMiddleware gives every SSH session an id and a logger carrying the
+// id, user and remote address, and logs when the session starts and ends
+func LoggingMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			id := randomID()
+			logger := log.With("session", id, "user", s.User(), "remote_addr", s.RemoteAddr().String())
+			s.Context().SetValue(sessionIDContextKey, id)
+			s.Context().SetValue(sessionLoggerContextKey, logger)
+
+			pty, _, _ := s.Pty()
+			logger.Info("Session started",
+				"transport", transportSSH,
+				"command", s.Command(),
+				"term", pty.Term,
+				"width", pty.Window.Width,
+				"height", pty.Window.Height,
+				"client", s.Context().ClientVersion(),
+				"public_key", s.PublicKey() != nil,
+			)
+
+			start := time.Now()
+			next(s)
+			logger.Info("Session ended", "duration", time.Since(start).Round(time.Millisecond))
+		}
+	}
+}
+
+// httpErrorLog sends an http.Server's errors to the default logger
+func httpErrorLog() *stdlog.Logger {
+	return log.StandardLog(log.StandardLogOptions{ForceLevel: log.ErrorLevel})
+}
+
+// sessionLogger returns the logger for an SSH session, or the default logger
+// if LoggingMiddleware didn't run
+func sessionLogger(ctx context.Context) *log.Logger {
+	if logger, ok := ctx.Value(sessionLoggerContextKey).(*log.Logger); ok {
+		return logger
+	}
+	return log.Default()
+}
+
+// sessionID returns the id LoggingMiddleware gave an SSH session
+func sessionID(ctx context.Context) string {
+	if id, ok := ctx.Value(sessionIDContextKey).(string); ok {
+		return id
+	}
+	return randomID()
+}
@@ -1,67 +1,285 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"net/http"
+	"slices"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
-	"github.com/charmbracelet/wish/logging"
+	"github.com/muesli/termenv"
 )
 
 // Server configuration
 type ServerConfig struct {
 	Host       string
 	Port       uint
-	SSHKeyPath string
+	Sections   []Section // Navigation sections, in tab order
+	Effects    bool      // Whether particle effects start enabled
+	Theme      string    // Default theme; empty to use the data file's
+	Accessible bool      // Whether sessions start in accessible mode
+	Themes     []*Theme  // Themes sessions can use, in cycling order (see AvailableThemes)
 	DataLoader *DataLoader
+	Stats      *ServerStats
+	Programs   *ProgramRegistry
+	Limiter    *SessionLimiter
+	Metrics    *Metrics   // nil when metrics are disabled
+	Analytics  *Analytics // nil when analytics are disabled
 }
 
-func NewServer(host string, port uint, sshKeyPath, dataPath string) (*ssh.Server, error) {
-	log.Printf("Starting SSH server on %s:%d", host, port)
-	log.Printf("Connect with: ssh %s -p %d", host, port)
-	log.Printf("Loading portfolio data from: %s", dataPath)
+// sectionEnabled reports whether a section is configured to be shown. Every
+// section is shown when none are configured.
+func (c *ServerConfig) sectionEnabled(section Section) bool {
+	return len(c.Sections) == 0 || slices.Contains(c.Sections, section)
+}
+
+// Server runs the SSH server and, when enabled, the HTTP server that serves
+// the same portfolio to browsers
+type Server struct {
+	SSH     *ssh.Server
+	HTTP    *http.Server // nil when no HTTP address is set
+	Metrics *http.Server // nil when no metrics address is set
 
-	// Initialize data loader
-	dataLoader := NewDataLoader(dataPath)
+	cancelWeb context.CancelFunc
+	analytics *Analytics
+	watcher   *DataWatcher // nil when the data isn't watched
+}
+
+// NewServer creates the servers described by cfg, which is validated first.
+// userThemes are the themes loaded from cfg.Themes (see LoadThemes).
+func NewServer(cfg Config, userThemes []*Theme) (*Server, error) {
+	if err := cfg.Validate(userThemes); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	dataFormat, _ := ParseDataFormat(cfg.DataFormat)
+	sections, _ := cfg.sections()
+	watchInterval := time.Duration(cfg.Watch)
+	themes := AvailableThemes(userThemes)
+
+	log.Info("Starting SSH server", "addr", fmt.Sprintf("%s:%d", cfg.Host, cfg.Port))
+	log.Info(fmt.Sprintf("Connect with: ssh %s -p %d", cfg.Host, cfg.Port))
+	if len(userThemes) > 0 {
+		log.Info("Loaded themes", "dir", cfg.Themes, "themes", themeNames(userThemes))
+	}
+	log.Info("Loading portfolio data", "path", cfg.Data)
+
+	dataLoader, err := loadPortfolioData(cfg.Data, dataFormat, themes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a server config to pass around
+	config := &ServerConfig{
+		Host:       cfg.Host,
+		Port:       cfg.Port,
+		Sections:   sections,
+		Effects:    cfg.Effects,
+		Accessible: cfg.Accessible,
+		Theme:      cfg.Theme,
+		Themes:     themes,
+		DataLoader: dataLoader,
+		Stats:      NewServerStats(),
+		Programs:   NewProgramRegistry(),
+		Limiter:    NewSessionLimiter(cfg.SessionLimits()),
+	}
+	if cfg.Metrics != "" {
+		config.Metrics = NewMetrics(config.Stats)
+	}
+	if cfg.Analytics != "" {
+		log.Info("Recording visitor analytics", "path", cfg.Analytics)
+		if config.Analytics, err = OpenAnalytics(cfg.Analytics); err != nil {
+			return nil, err
+		}
+	}
+
+	// Watch the data file and push changes to every open session
+	var watcher *DataWatcher
+	if watchInterval > 0 {
+		log.Info("Watching data for changes", "path", cfg.Data, "interval", watchInterval)
+		watcher = NewDataWatcher(dataLoader, config.Programs, config.Metrics, watchInterval)
+		watcher.Start()
+	}
+
+	options := []ssh.Option{wish.WithAddress(fmt.Sprintf("%s:%d", cfg.Host, cfg.Port))}
+	for _, path := range cfg.HostKeys {
+		options = append(options, wish.WithHostKeyPath(path))
+	}
+	sshServer, err := wish.NewServer(append(options,
+		wish.WithMiddleware(
+			bubbletea.MiddlewareWithProgramHandler(func(s ssh.Session) *tea.Program {
+				return programHandler(s, config)
+			}, termenv.Ascii),
+			CommandMiddleware(config),
+			config.Stats.Middleware(),
+			config.Metrics.Middleware(),
+			config.Limiter.Middleware(),
+			LoggingMiddleware(),
+		),
+	)...)
+	if err != nil {
+		watcher.Stop()
+		config.Analytics.Close()
+		return nil, err
+	}
+
+	srv := &Server{SSH: sshServer, analytics: config.Analytics, watcher: watcher}
+	if cfg.HTTP != "" {
+		log.Info("Serving the browser terminal", "url", "http://"+cfg.HTTP)
+
+		// Web sessions are hijacked connections, which http.Server.Shutdown
+		// doesn't close, so they are ended through this context
+		ctx, cancel := context.WithCancel(context.Background())
+		srv.cancelWeb = cancel
+		srv.HTTP = &http.Server{
+			Addr:              cfg.HTTP,
+			Handler:           newWebHandler(ctx, config),
+			ReadHeaderTimeout: 10 * time.Second,
+			ErrorLog:          httpErrorLog(),
+		}
+	}
+
+	if cfg.Metrics != "" {
+		log.Info("Serving metrics", "url", "http://"+cfg.Metrics+"/metrics")
+		srv.Metrics = &http.Server{
+			Addr:              cfg.Metrics,
+			Handler:           config.Metrics.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+			ErrorLog:          httpErrorLog(),
+		}
+	}
+
+	return srv, nil
+}
+
+// ListenAndServe starts the servers and blocks until one of them stops. It
+// returns nil when the servers were shut down.
+func (s *Server) ListenAndServe() error {
+	errs := make(chan error, 3)
+
+	for _, server := range []*http.Server{s.HTTP, s.Metrics} {
+		if server != nil {
+			go func() {
+				errs <- server.ListenAndServe()
+			}()
+		}
+	}
+	go func() {
+		errs <- s.SSH.ListenAndServe()
+	}()
+
+	err := <-errs
+	if errors.Is(err, ssh.ErrServerClosed) || errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown gracefully stops the servers, ending open web sessions
+func (s *Server) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if s.HTTP != nil {
+		s.cancelWeb()
+		if err := s.HTTP.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Metrics != nil {
+		if err := s.Metrics.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.SSH.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	s.watcher.Stop()
+	if err := s.analytics.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadPortfolioData loads and validates the data file, logging any warnings.
+// The data may pick any of themes.
+func loadPortfolioData(dataPath string, dataFormat DataFormat, themes []*Theme) (*DataLoader, error) {
+	dataLoader := NewDataLoader(dataPath, dataFormat)
 	if err := dataLoader.LoadData(); err != nil {
 		return nil, fmt.Errorf("Warning: Failed to load portfolio data: %v", err)
 	}
 
 	// Validate loaded data
 	if dataLoader.IsLoaded() {
-		if err := dataLoader.ValidateData(); err != nil {
+		issues := dataLoader.Validate(themes)
+		for _, warning := range ValidationWarnings(issues) {
+			log.Warn("Data warning", "field", warning.Path, "issue", warning.Message)
+		}
+		if err := validationError(issues); err != nil {
 			return nil, fmt.Errorf("Warning: Data validation failed: %v", err)
 		}
 	}
 
-	// Create a server config to pass around
-	config := &ServerConfig{
-		Host:       host,
-		Port:       port,
-		SSHKeyPath: sshKeyPath,
-		DataLoader: dataLoader,
+	return dataLoader, nil
+}
+
+// programHandler creates the tea program for a session and keeps it registered
+// for broadcasts until the session ends
+func programHandler(s ssh.Session, config *ServerConfig) *tea.Program {
+	model, opts := teaHandler(s, config)
+	if model == nil {
+		// No PTY; CommandMiddleware has already answered the session
+		return nil
 	}
 
-	return wish.NewServer(
-		wish.WithAddress(fmt.Sprintf("%s:%d", host, port)),
-		wish.WithHostKeyPath(sshKeyPath),
-		wish.WithMiddleware(
-			bubbletea.Middleware(func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
-				return teaHandler(s, config)
-			}),
-			logging.Middleware(),
-		),
-	)
+	// The background is detected while the session runs; until the terminal
+	// answers, the session shows the starting theme
+	var input io.Reader = s
+	var background <-chan bool
+	if pty, _, _ := s.Pty(); pty.Term != "" && pty.Term != "dumb" {
+		if _, err := io.WriteString(s, backgroundQuery); err == nil {
+			input, background = detectBackground(s.Context(), s)
+		}
+	}
+	p := tea.NewProgram(model, append(opts, tea.WithInput(input), tea.WithOutput(s))...)
+	if background != nil {
+		go func() {
+			select {
+			case dark := <-background:
+				p.Send(backgroundMsg{dark: dark})
+			case <-s.Context().Done():
+			}
+		}()
+	}
+
+	config.Programs.Register(p)
+	go func() {
+		<-s.Context().Done()
+		config.Programs.Unregister(p)
+		model.analytics.End()
+	}()
+
+	return p
 }
 
-func teaHandler(s ssh.Session, config *ServerConfig) (tea.Model, []tea.ProgramOption) {
+func teaHandler(s ssh.Session, config *ServerConfig) (*PortfolioModel, []tea.ProgramOption) {
 	// Get terminal dimensions
-	pty, _, _ := s.Pty()
+	pty, _, ok := s.Pty()
+	if !ok {
+		return nil, nil
+	}
 
-	model := NewPortfolioModel(int(pty.Window.Width), int(pty.Window.Height), config.DataLoader)
+	model := NewPortfolioModelWithRenderer(int(pty.Window.Width), int(pty.Window.Height), config, bubbletea.MakeRenderer(s))
+	model.setAccessible(envAccessible(s.Environ(), config.Accessible))
+	model.logger = sessionLogger(s.Context())
+	model.analytics = config.Analytics.StartSession(sessionID(s.Context()), transportSSH,
+		remoteIP(s.RemoteAddr()), pty.Term, pty.Window.Width, pty.Window.Height)
 
 	return model, []tea.ProgramOption{
 		tea.WithAltScreen(),
@@ -0,0 +1,589 @@
+package server
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// A small Markdown dialect for portfolio text fields: ATX headings, paragraphs,
+// "-", "*", "+" and numbered lists, ``` and ~~~ fenced code blocks, and
+// inline **bold**, *italic*, `code` and [links](url). Parsing is kept separate
+// from rendering so the same document can be rendered for the terminal or
+// exported.
+
+type mdBlockKind int
+
+const (
+	mdParagraph mdBlockKind = iota
+	mdHeading
+	mdList
+	mdCodeBlock
+)
+
+type mdBlock struct {
+	kind    mdBlockKind
+	level   int      // Heading level
+	ordered bool     // Numbered list
+	text    string   // Paragraph, heading or code block text
+	items   []string // List items
+}
+
+type mdSpanKind int
+
+const (
+	mdText mdSpanKind = iota
+	mdBold
+	mdItalic
+	mdCode
+	mdLink
+)
+
+type mdSpan struct {
+	kind mdSpanKind
+	text string
+	url  string // Link target
+}
+
+// parseMarkdownBlocks splits a Markdown document into block level elements
+func parseMarkdownBlocks(src string) []mdBlock {
+	var blocks []mdBlock
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, mdBlock{kind: mdParagraph, text: strings.Join(paragraph, " ")})
+			paragraph = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+
+		case codeFence(trimmed) != "":
+			flushParagraph()
+			fence := codeFence(trimmed)
+			var code []string
+			for i++; i < len(lines) && codeFence(lines[i]) != fence; i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, mdBlock{kind: mdCodeBlock, text: strings.Join(code, "\n")})
+
+		case headingLevel(trimmed) > 0:
+			flushParagraph()
+			level := headingLevel(trimmed)
+			blocks = append(blocks, mdBlock{
+				kind:  mdHeading,
+				level: level,
+				text:  strings.TrimSpace(trimmed[level:]),
+			})
+
+		default:
+			item, ordered, ok := cutMarkdownListItem(trimmed, false)
+			if !ok {
+				paragraph = append(paragraph, trimmed)
+				continue
+			}
+
+			flushParagraph()
+			list := mdBlock{kind: mdList, ordered: ordered}
+			list.items = append(list.items, item)
+
+			// Collect following items and their continuation lines. Headings,
+			// code fences and the other kind of list end the list.
+			for i+1 < len(lines) {
+				next := strings.TrimSpace(lines[i+1])
+				if next == "" || headingLevel(next) > 0 || codeFence(next) != "" {
+					break
+				}
+				if item, ordered, ok := cutMarkdownListItem(next, list.ordered); ok {
+					if ordered != list.ordered {
+						break
+					}
+					list.items = append(list.items, item)
+				} else {
+					list.items[len(list.items)-1] += " " + next
+				}
+				i++
+			}
+			blocks = append(blocks, list)
+		}
+	}
+	flushParagraph()
+
+	return blocks
+}
+
+// codeFence returns the marker of a line opening or closing a fenced code
+// block, "```" or "~~~", or ""
+func codeFence(line string) string {
+	line = strings.TrimSpace(line)
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// cutMarkdownListItem strips a list marker from a line, reporting whether
+// the marker is a number. A numbered list has to start at 1, so a line
+// starting with a year, like "2024. Shipped", stays text unless it continues
+// a numbered list.
+func cutMarkdownListItem(line string, inNumberedList bool) (item string, ordered, ok bool) {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if item, ok := strings.CutPrefix(line, marker); ok {
+			return strings.TrimSpace(item), false, true
+		}
+	}
+
+	digits := strings.IndexFunc(line, func(r rune) bool { return r < '0' || r > '9' })
+	if digits <= 0 || !strings.HasPrefix(line[digits:], ". ") {
+		return "", false, false
+	}
+	if !inNumberedList && line[:digits] != "1" {
+		return "", false, false
+	}
+	return strings.TrimSpace(line[digits+2:]), true, true
+}
+
+// headingLevel returns the level of an ATX heading line, or 0
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// parseMarkdownInline splits a line of Markdown text into styled spans
+func parseMarkdownInline(src string) []mdSpan {
+	var spans []mdSpan
+	var text strings.Builder
+
+	flushText := func() {
+		if text.Len() > 0 {
+			spans = append(spans, mdSpan{kind: mdText, text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(src); {
+		rest := src[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			text.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				flushText()
+				spans = append(spans, mdSpan{kind: mdCode, text: rest[1 : end+1]})
+				i += end + 2
+				continue
+			}
+
+		case rest[0] == '_' && i > 0 && isWordByte(src[i-1]):
+			// Underscores inside words (snake_case) are literal
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delim := rest[:2]
+			if end := strings.Index(rest[2:], delim); end > 0 {
+				flushText()
+				spans = append(spans, mdSpan{kind: mdBold, text: rest[2 : end+2]})
+				i += end + 4
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			delim := rest[:1]
+			if end := strings.Index(rest[1:], delim); end > 0 && rest[1] != ' ' {
+				flushText()
+				spans = append(spans, mdSpan{kind: mdItalic, text: rest[1 : end+1]})
+				i += end + 2
+				continue
+			}
+
+		case rest[0] == '[':
+			if label, url, n, ok := cutLink(rest); ok {
+				flushText()
+				spans = append(spans, mdSpan{kind: mdLink, text: label, url: url})
+				i += n
+				continue
+			}
+		}
+
+		text.WriteByte(rest[0])
+		i++
+	}
+	flushText()
+
+	return spans
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// cutLink parses "[label](url)" at the start of s. The label ends at the "]"
+// matching the opening "[", which has to be followed by "(".
+func cutLink(s string) (label, url string, n int, ok bool) {
+	closeLabel, depth := -1, 0
+	for i := 0; i < len(s) && closeLabel < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				closeLabel = i
+			}
+		}
+	}
+	if closeLabel < 0 || !strings.HasPrefix(s[closeLabel+1:], "(") {
+		return "", "", 0, false
+	}
+
+	closeURL := strings.IndexByte(s[closeLabel:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	closeURL += closeLabel
+
+	return s[1:closeLabel], s[closeLabel+2 : closeURL], closeURL + 1, true
+}
+
+// renderMarkdown renders a Markdown document for the terminal, wrapping text
+// to width (no wrapping when width <= 0). Plain text uses the base style.
+func renderMarkdown(src string, width int, base lipgloss.Style, styles *PortfolioStyles) string {
+	var out []string
+
+	for _, block := range parseMarkdownBlocks(src) {
+		switch block.kind {
+		case mdHeading:
+			out = append(out, renderMarkdownInline(block.text, width, styles.MarkdownHeading, styles))
+
+		case mdParagraph:
+			out = append(out, renderMarkdownInline(block.text, width, base, styles))
+
+		case mdList:
+			var items []string
+			for i, item := range block.items {
+				marker := "•"
+				if block.ordered {
+					marker = strconv.Itoa(i+1) + "."
+				}
+				items = append(items, renderMarkdownListItem(marker, item, width, base, styles))
+			}
+			out = append(out, strings.Join(items, "\n"))
+
+		case mdCodeBlock:
+			out = append(out, styles.MarkdownCodeBlock.Render(block.text))
+		}
+	}
+
+	return strings.Join(out, "\n\n")
+}
+
+// renderMarkdownListItem renders a list item with a hanging indent
+func renderMarkdownListItem(marker, item string, width int, base lipgloss.Style, styles *PortfolioStyles) string {
+	indent := lipgloss.Width(marker) + 1
+	if width > 0 {
+		width = max(width-indent, 10)
+	}
+
+	lines := strings.Split(renderMarkdownInline(item, width, base, styles), "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = styles.MarkdownBullet.Render(marker) + " " + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", indent) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mdFragment is a piece of a word rendered in a single style
+type mdFragment struct {
+	text  string
+	style lipgloss.Style
+}
+
+// renderMarkdownInline renders a single paragraph of inline Markdown, wrapping
+// on word boundaries. Words are styled individually so no style spans a line
+// break.
+func renderMarkdownInline(src string, width int, base lipgloss.Style, styles *PortfolioStyles) string {
+	base = inlineStyle(base)
+
+	var words [][]mdFragment
+	var word []mdFragment
+
+	endWord := func() {
+		if len(word) > 0 {
+			words = append(words, word)
+			word = nil
+		}
+	}
+
+	for _, span := range parseMarkdownInline(src) {
+		style := base
+		text := span.text
+		switch span.kind {
+		case mdBold:
+			style = styles.MarkdownBold.Inherit(base)
+		case mdItalic:
+			style = styles.MarkdownItalic.Inherit(base)
+		case mdCode:
+			// Code spans are never broken across lines
+			word = append(word, mdFragment{text: text, style: styles.MarkdownCode.Inherit(base)})
+			continue
+		case mdLink:
+			style = styles.MarkdownLink.Inherit(base)
+			if span.url != "" && span.url != span.text {
+				text += " (" + span.url + ")"
+			}
+		}
+
+		for i, part := range strings.Split(text, " ") {
+			if i > 0 {
+				endWord()
+			}
+			if part != "" {
+				word = append(word, mdFragment{text: part, style: style})
+			}
+		}
+	}
+	endWord()
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+
+	for _, w := range breakLongWords(words, width) {
+		var rendered strings.Builder
+		wordWidth := 0
+		for _, fragment := range w {
+			rendered.WriteString(fragment.style.Render(fragment.text))
+			wordWidth += lipgloss.Width(fragment.text)
+		}
+
+		if lineWidth > 0 && width > 0 && lineWidth+1+wordWidth > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+		if lineWidth > 0 {
+			line.WriteString(base.Render(" "))
+			lineWidth++
+		}
+		line.WriteString(rendered.String())
+		lineWidth += wordWidth
+	}
+	lines = append(lines, line.String())
+
+	return strings.Join(lines, "\n")
+}
+
+// breakLongWords breaks words wider than width into pieces that fit, so
+// long URLs and the like don't run past the edge
+func breakLongWords(words [][]mdFragment, width int) [][]mdFragment {
+	if width <= 0 {
+		return words
+	}
+
+	var out [][]mdFragment
+	for _, w := range words {
+		wordWidth := 0
+		for _, fragment := range w {
+			wordWidth += lipgloss.Width(fragment.text)
+		}
+		if wordWidth <= width {
+			out = append(out, w)
+			continue
+		}
+
+		var piece []mdFragment
+		pieceWidth := 0
+		for _, fragment := range w {
+			var text strings.Builder
+			for _, r := range fragment.text {
+				runeWidth := ansi.StringWidth(string(r))
+				if pieceWidth+runeWidth > width && pieceWidth > 0 {
+					if text.Len() > 0 {
+						piece = append(piece, mdFragment{text: text.String(), style: fragment.style})
+						text.Reset()
+					}
+					out = append(out, piece)
+					piece, pieceWidth = nil, 0
+				}
+				text.WriteRune(r)
+				pieceWidth += runeWidth
+			}
+			if text.Len() > 0 {
+				piece = append(piece, mdFragment{text: text.String(), style: fragment.style})
+			}
+		}
+		if len(piece) > 0 {
+			out = append(out, piece)
+		}
+	}
+	return out
+}
+
+// inlineStyle strips the layout properties of a style so it can be applied to
+// individual words
+func inlineStyle(style lipgloss.Style) lipgloss.Style {
+	return style.
+		UnsetMargins().
+		UnsetPadding().
+		UnsetBorderStyle().
+		UnsetBorderTop().
+		UnsetBorderRight().
+		UnsetBorderBottom().
+		UnsetBorderLeft().
+		UnsetWidth().
+		UnsetHeight().
+		UnsetAlign()
+}
+
+// plainMarkdownInline returns inline Markdown as plain text. Links keep their
+// target in parentheses, as in the terminal.
+func plainMarkdownInline(src string) string {
+	var b strings.Builder
+	for _, span := range parseMarkdownInline(src) {
+		b.WriteString(span.text)
+		if span.kind == mdLink && span.url != "" && span.url != span.text {
+			b.WriteString(" (" + span.url + ")")
+		}
+	}
+	return b.String()
+}
+
+// plainMarkdown renders a Markdown document as plain text wrapped to width
+func plainMarkdown(src string, width int) string {
+	var out []string
+
+	for _, block := range parseMarkdownBlocks(src) {
+		switch block.kind {
+		case mdHeading:
+			out = append(out, ansi.Wrap(plainMarkdownInline(block.text), width, ""))
+
+		case mdParagraph:
+			out = append(out, ansi.Wrap(plainMarkdownInline(block.text), width, ""))
+
+		case mdList:
+			var items []string
+			for i, item := range block.items {
+				marker := "-"
+				if block.ordered {
+					marker = strconv.Itoa(i+1) + "."
+				}
+				items = append(items, plainListItem(marker, plainMarkdownInline(item), width))
+			}
+			out = append(out, strings.Join(items, "\n"))
+
+		case mdCodeBlock:
+			out = append(out, indentLines(block.text, 4))
+		}
+	}
+
+	return strings.Join(out, "\n\n")
+}
+
+// plainListItem wraps a list item with a hanging indent
+func plainListItem(marker, item string, width int) string {
+	indent := len(marker) + 1
+	wrapped := ansi.Wrap(item, max(width-indent, 10), "")
+	return marker + " " + strings.ReplaceAll(wrapped, "\n", "\n"+strings.Repeat(" ", indent))
+}
+
+// htmlMarkdownInline renders inline Markdown as escaped HTML
+func htmlMarkdownInline(src string) string {
+	var b strings.Builder
+	for _, span := range parseMarkdownInline(src) {
+		text := html.EscapeString(span.text)
+		switch span.kind {
+		case mdBold:
+			b.WriteString("<strong>" + text + "</strong>")
+		case mdItalic:
+			b.WriteString("<em>" + text + "</em>")
+		case mdCode:
+			b.WriteString("<code>" + text + "</code>")
+		case mdLink:
+			if href := linkHref(span.url); href != "" {
+				b.WriteString(`<a href="` + html.EscapeString(href) + `">` + text + "</a>")
+			} else {
+				b.WriteString(text)
+			}
+		default:
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
+
+// htmlMarkdown renders a Markdown document as HTML. Headings start at level
+// four so they nest below the page's own section headings.
+func htmlMarkdown(src string) string {
+	var out []string
+
+	for _, block := range parseMarkdownBlocks(src) {
+		switch block.kind {
+		case mdHeading:
+			tag := "h" + strconv.Itoa(min(block.level+3, 6))
+			out = append(out, "<"+tag+">"+htmlMarkdownInline(block.text)+"</"+tag+">")
+
+		case mdParagraph:
+			out = append(out, "<p>"+htmlMarkdownInline(block.text)+"</p>")
+
+		case mdList:
+			tag := "ul"
+			if block.ordered {
+				tag = "ol"
+			}
+			var list strings.Builder
+			list.WriteString("<" + tag + ">")
+			for _, item := range block.items {
+				list.WriteString("<li>" + htmlMarkdownInline(item) + "</li>")
+			}
+			list.WriteString("</" + tag + ">")
+			out = append(out, list.String())
+
+		case mdCodeBlock:
+			out = append(out, "<pre><code>"+html.EscapeString(block.text)+"</code></pre>")
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// linkHref turns a link target into an href, adding https:// to scheme-less
+// links like github.com/user. Returns "" for schemes that aren't safe to link.
+func linkHref(target string) string {
+	lower := strings.ToLower(target)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "mailto:"):
+		return target
+	case strings.Contains(lower, ":"):
+		return ""
+	case isLinkLike(target):
+		return "https://" + target
+	default:
+		return ""
+	}
+}
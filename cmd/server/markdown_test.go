@@ -0,0 +1,290 @@
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParseMarkdownInline(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []mdSpan
+	}{
+		{"plain text", []mdSpan{{kind: mdText, text: "plain text"}}},
+		{"a **bold** word", []mdSpan{
+			{kind: mdText, text: "a "},
+			{kind: mdBold, text: "bold"},
+			{kind: mdText, text: " word"},
+		}},
+		{"__bold__ and _italic_", []mdSpan{
+			{kind: mdBold, text: "bold"},
+			{kind: mdText, text: " and "},
+			{kind: mdItalic, text: "italic"},
+		}},
+		{"*italic*", []mdSpan{{kind: mdItalic, text: "italic"}}},
+		{"2 * 3 * 4", []mdSpan{{kind: mdText, text: "2 * 3 * 4"}}},
+		{"snake_case_name", []mdSpan{{kind: mdText, text: "snake_case_name"}}},
+		{"run `go test`", []mdSpan{
+			{kind: mdText, text: "run "},
+			{kind: mdCode, text: "go test"},
+		}},
+		{"`**not bold**`", []mdSpan{{kind: mdCode, text: "**not bold**"}}},
+		{`\*literal\*`, []mdSpan{{kind: mdText, text: "*literal*"}}},
+		{"see [my site](https://example.com).", []mdSpan{
+			{kind: mdText, text: "see "},
+			{kind: mdLink, text: "my site", url: "https://example.com"},
+			{kind: mdText, text: "."},
+		}},
+		{"[unclosed](link", []mdSpan{{kind: mdText, text: "[unclosed](link"}}},
+		{"see [docs] and [site](https://x.io)", []mdSpan{
+			{kind: mdText, text: "see [docs] and "},
+			{kind: mdLink, text: "site", url: "https://x.io"},
+		}},
+		{"[a [nested] label](https://x.io)", []mdSpan{
+			{kind: mdLink, text: "a [nested] label", url: "https://x.io"},
+		}},
+		{"[not] (a link)", []mdSpan{{kind: mdText, text: "[not] (a link)"}}},
+	}
+
+	for _, tt := range tests {
+		if got := parseMarkdownInline(tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseMarkdownInline(%q) = %+v, want %+v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseMarkdownBlocks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []mdBlock
+	}{
+		{
+			name: "paragraphs",
+			src:  "One\nline.\n\nTwo.",
+			want: []mdBlock{
+				{kind: mdParagraph, text: "One line."},
+				{kind: mdParagraph, text: "Two."},
+			},
+		},
+		{
+			name: "heading",
+			src:  "## Title\nText",
+			want: []mdBlock{
+				{kind: mdHeading, level: 2, text: "Title"},
+				{kind: mdParagraph, text: "Text"},
+			},
+		},
+		{
+			name: "hashtag",
+			src:  "#golang",
+			want: []mdBlock{{kind: mdParagraph, text: "#golang"}},
+		},
+		{
+			name: "bullet list",
+			src:  "- one\n* two\n  continued\n+ three",
+			want: []mdBlock{{kind: mdList, items: []string{"one", "two continued", "three"}}},
+		},
+		{
+			name: "numbered list",
+			src:  "Steps:\n1. one\n2. two",
+			want: []mdBlock{
+				{kind: mdParagraph, text: "Steps:"},
+				{kind: mdList, ordered: true, items: []string{"one", "two"}},
+			},
+		},
+		{
+			name: "year at the start of a line",
+			src:  "2024. Shipped the new site.",
+			want: []mdBlock{{kind: mdParagraph, text: "2024. Shipped the new site."}},
+		},
+		{
+			name: "year inside a paragraph",
+			src:  "A big year.\n2024. Shipped it.",
+			want: []mdBlock{{kind: mdParagraph, text: "A big year. 2024. Shipped it."}},
+		},
+		{
+			name: "year after a bullet",
+			src:  "- launched\n2024. Shipped it.",
+			want: []mdBlock{{kind: mdList, items: []string{"launched 2024. Shipped it."}}},
+		},
+		{
+			name: "heading after a list",
+			src:  "- one\n## Heading\nText",
+			want: []mdBlock{
+				{kind: mdList, items: []string{"one"}},
+				{kind: mdHeading, level: 2, text: "Heading"},
+				{kind: mdParagraph, text: "Text"},
+			},
+		},
+		{
+			name: "code block after a list",
+			src:  "- one\n```\ncode\n```",
+			want: []mdBlock{
+				{kind: mdList, items: []string{"one"}},
+				{kind: mdCodeBlock, text: "code"},
+			},
+		},
+		{
+			name: "numbered list after a bullet list",
+			src:  "- one\n1. two\n2. three\n- four",
+			want: []mdBlock{
+				{kind: mdList, items: []string{"one"}},
+				{kind: mdList, ordered: true, items: []string{"two", "three"}},
+				{kind: mdList, items: []string{"four"}},
+			},
+		},
+		{
+			name: "code block",
+			src:  "```go\nfmt.Println(\"# not a heading\")\n\n- not a list\n```\nAfter",
+			want: []mdBlock{
+				{kind: mdCodeBlock, text: "fmt.Println(\"# not a heading\")\n\n- not a list"},
+				{kind: mdParagraph, text: "After"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMarkdownBlocks(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"[site](https://example.com)", `<a href="https://example.com">site</a>`},
+		{"[me](mailto:me@example.com)", `<a href="mailto:me@example.com">me</a>`},
+		{"[code](github.com/me)", `<a href="https://github.com/me">code</a>`},
+		{"[dev](http://localhost:8080)", `<a href="http://localhost:8080">dev</a>`},
+		{"[click](javascript:alert%281%29)", "click"},
+		{"[click](JavaScript:void)", "click"},
+		{"[data](data:text/html,hi)", "data"},
+		{"[local](notes)", "local"},
+		{"[a <b>](https://example.com/?a=1&b=2)", `<a href="https://example.com/?a=1&amp;b=2">a &lt;b&gt;</a>`},
+		{"**<script>**", "<strong>&lt;script&gt;</strong>"},
+	}
+
+	for _, tt := range tests {
+		if got := htmlMarkdownInline(tt.src); got != tt.want {
+			t.Errorf("htmlMarkdownInline(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestHTMLMarkdownLists(t *testing.T) {
+	got := htmlMarkdown("- one\n- *two*\n\n1. first\n2. second")
+	want := "<ul><li>one</li><li><em>two</em></li></ul>\n<ol><li>first</li><li>second</li></ol>"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPlainMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  string
+	}{
+		{
+			name:  "emphasis and links",
+			src:   "A **bold** [site](example.com) and `code`.",
+			width: 80,
+			want:  "A bold site (example.com) and code.",
+		},
+		{
+			name:  "wrapped paragraph",
+			src:   "one two three four five",
+			width: 10,
+			want:  "one two\nthree four\nfive",
+		},
+		{
+			name:  "long word",
+			src:   "see https://example.com/a/long/path",
+			width: 12,
+			want:  "see\nhttps://exam\nple.com/a/lo\nng/path",
+		},
+		{
+			name:  "list and numbers not starting at 1",
+			src:   "- alpha beta gamma delta\n\n3. one\n4. two",
+			width: 14,
+			want:  "- alpha beta\n  gamma delta\n\n3. one 4. two",
+		},
+		{
+			name:  "numbered list",
+			src:   "1. one\n2. two",
+			width: 80,
+			want:  "1. one\n2. two",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := plainMarkdown(tt.src, tt.width); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownWrapping(t *testing.T) {
+	styles := NewPortfolioStyles(builtinThemes[0])
+	base := lipgloss.NewStyle()
+
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  []string
+	}{
+		{
+			name:  "words",
+			src:   "one **two** three four",
+			width: 9,
+			want:  []string{"one two", "three", "four"},
+		},
+		{
+			name:  "long word",
+			src:   "a https://example.com/long",
+			width: 10,
+			want:  []string{"a", "https://ex", "ample.com/", "long"},
+		},
+		{
+			name:  "long styled word",
+			src:   "**abcdef**ghij",
+			width: 4,
+			want:  []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:  "wide characters",
+			src:   "日本語のテキスト",
+			width: 6,
+			want:  []string{"日本語", "のテキ", "スト"},
+		},
+		{
+			name:  "no wrapping",
+			src:   "one two three",
+			width: 0,
+			want:  []string{"one two three"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(ansi.Strip(renderMarkdown(tt.src, tt.width, base, styles)), "\n")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "portfolio"
+
+// Session transports, used as metric labels
+const (
+	transportSSH = "ssh"
+	transportWeb = "web"
+)
+
+// Metrics exposes server activity to Prometheus. A nil *Metrics records
+// nothing, so callers don't need to check whether metrics are enabled.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	sessionsStarted *prometheus.CounterVec
+	sessionsEnded   *prometheus.CounterVec
+	sessionDuration *prometheus.HistogramVec
+	sectionViews    *prometheus.CounterVec
+	explosions      prometheus.Counter
+	dataReloads     *prometheus.CounterVec
+	renderDuration  prometheus.Histogram
+}
+
+// NewMetrics creates the metrics, along with Go runtime and process metrics
+// and a gauge of the sessions currently tracked by stats
+func NewMetrics(stats *ServerStats) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		sessionsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sessions_started_total",
+			Help:      "Sessions started, by transport.",
+		}, []string{"transport"}),
+		sessionsEnded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sessions_ended_total",
+			Help:      "Sessions ended, by transport.",
+		}, []string{"transport"}),
+		sessionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "session_duration_seconds",
+			Help:      "Length of sessions, by transport.",
+			Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		}, []string{"transport"}),
+		sectionViews: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "section_views_total",
+			Help:      "Times each section was opened in the interactive portfolio.",
+		}, []string{"section"}),
+		explosions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "explosions_total",
+			Help:      "Particle explosions triggered.",
+		}),
+		dataReloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "data_reloads_total",
+			Help:      "Portfolio data reloads, by result.",
+		}, []string{"result"}),
+		renderDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "view_render_seconds",
+			Help:      "Time taken to render a frame of the interactive portfolio.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 12),
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.sessionsStarted,
+		m.sessionsEnded,
+		m.sessionDuration,
+		m.sectionViews,
+		m.explosions,
+		m.dataReloads,
+		m.renderDuration,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "sessions_active",
+			Help:      "Sessions currently connected.",
+		}, func() float64 {
+			return float64(stats.ActiveSessions())
+		}),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	// Start every series at zero so rates work from the first scrape
+	for _, transport := range []string{transportSSH, transportWeb} {
+		m.sessionsStarted.WithLabelValues(transport)
+		m.sessionsEnded.WithLabelValues(transport)
+	}
+	for section := AboutSection; section <= HelpSection; section++ {
+		m.sectionViews.WithLabelValues(section.String())
+	}
+	for _, result := range []string{"success", "failure"} {
+		m.dataReloads.WithLabelValues(result)
+	}
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	return mux
+}
+
+// Middleware records SSH sessions as they start and end
+func (m *Metrics) Middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			defer m.SessionStarted(transportSSH)()
+			next(s)
+		}
+	}
+}
+
+// SessionStarted records a new session and returns a function that records
+// its end
+func (m *Metrics) SessionStarted(transport string) func() {
+	if m == nil {
+		return func() {}
+	}
+
+	m.sessionsStarted.WithLabelValues(transport).Inc()
+	start := time.Now()
+	return func() {
+		m.sessionsEnded.WithLabelValues(transport).Inc()
+		m.sessionDuration.WithLabelValues(transport).Observe(time.Since(start).Seconds())
+	}
+}
+
+// SectionViewed records a section being opened
+func (m *Metrics) SectionViewed(section Section) {
+	if m == nil {
+		return
+	}
+	m.sectionViews.WithLabelValues(section.String()).Inc()
+}
+
+// ExplosionTriggered records a particle explosion
+func (m *Metrics) ExplosionTriggered() {
+	if m == nil {
+		return
+	}
+	m.explosions.Inc()
+}
+
+// DataReloaded records the result of reloading the portfolio data
+func (m *Metrics) DataReloaded(err error) {
+	if m == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.dataReloads.WithLabelValues(result).Inc()
+}
+
+// ViewRendered records how long a frame took to render since start
+func (m *Metrics) ViewRendered(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.renderDuration.Observe(time.Since(start).Seconds())
+}
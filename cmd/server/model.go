@@ -2,9 +2,9 @@ package server
 
 import (
 	"fmt"
-	"log"
 	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 )
 
 type Section int
@@ -20,10 +21,85 @@ const (
 	AboutSection Section = iota
 	ExperienceSection
 	SkillsSection
+	ProjectsSection
 	ContactSection
+	LiveSection
 	HelpSection // Add Help as a section but not in navigation
 )
 
+var sectionNames = [...]string{"about", "experience", "skills", "projects", "contact", "live", "help"}
+
+// sectionTitles are the names sections are shown with
+var sectionTitles = map[Section]string{
+	AboutSection:      "About",
+	ExperienceSection: "Experience",
+	SkillsSection:     "Skills",
+	ProjectsSection:   "Projects",
+	ContactSection:    "Contact",
+	LiveSection:       "Live",
+	HelpSection:       "Help",
+}
+
+// sectionIcons are shown before section titles
+var sectionIcons = map[Section]string{
+	AboutSection:      "👋",
+	ExperienceSection: "💼",
+	SkillsSection:     "🚀",
+	ProjectsSection:   "📦",
+	ContactSection:    "📞",
+	LiveSection:       "📡",
+	HelpSection:       "❓",
+}
+
+// sectionDescriptions say what each section holds, on the help section
+var sectionDescriptions = map[Section]string{
+	AboutSection:      "Personal introduction, current time, and tech facts",
+	ExperienceSection: "Professional work history",
+	SkillsSection:     "Technical expertise and proficiency",
+	ProjectsSection:   "Things I have built and am building",
+	ContactSection:    "Get in touch information",
+	LiveSection:       "Real-time server and session statistics",
+}
+
+// navigationSections are the sections shown as tabs when none are configured
+var navigationSections = []Section{
+	AboutSection,
+	ExperienceSection,
+	SkillsSection,
+	ProjectsSection,
+	ContactSection,
+	LiveSection,
+	// Help section excluded from normal navigation
+}
+
+// NavigationSectionNames returns the names of every section that can be
+// shown as a tab, in the default order
+func NavigationSectionNames() []string {
+	names := make([]string, len(navigationSections))
+	for i, section := range navigationSections {
+		names[i] = section.String()
+	}
+	return names
+}
+
+// String returns the section's lowercase name
+func (s Section) String() string {
+	if int(s) < len(sectionNames) {
+		return sectionNames[s]
+	}
+	return fmt.Sprintf("section(%d)", int(s))
+}
+
+// parseSection finds a section by its name
+func parseSection(name string) (Section, bool) {
+	for i, sectionName := range sectionNames {
+		if sectionName == strings.ToLower(strings.TrimSpace(name)) {
+			return Section(i), true
+		}
+	}
+	return 0, false
+}
+
 type PortfolioModel struct {
 	sections       []Section
 	currentSection Section
@@ -31,14 +107,33 @@ type PortfolioModel struct {
 	width          int
 	height         int
 	styles         *PortfolioStyles
+	theme          *Theme
+	themes         []*Theme // Themes the visitor can cycle through
+	themeChosen    bool     // Whether the visitor picked the theme, so detection leaves it alone
+	renderer       *lipgloss.Renderer
 	ready          bool
 	animationTick  int
 	dataLoader     *DataLoader
+	data           *PortfolioData // Snapshot used for the current render
+	stats          *ServerStats
+	programs       *ProgramRegistry
+	metrics        *Metrics
+	analytics      *AnalyticsSession // nil unless analytics are enabled
+	logger         *log.Logger
 
 	// Explosion effects only
 	particles      []Particle
 	effectsEnabled bool
 	startTime      time.Time
+
+	// Accessible mode: no emoji, no motion, high contrast and a linear layout
+	accessible            bool
+	themeBeforeAccessible *Theme
+
+	// Session limits, with the reason the session is closing once one is hit
+	limits    SessionLimits
+	lastInput time.Time
+	goodbye   string
 }
 
 // Particle system for explosions
@@ -57,34 +152,53 @@ const (
 	offsetWindowHeight int = 10
 )
 
-func NewPortfolioModel(width, height int, dataLoader *DataLoader) *PortfolioModel {
-	vp := viewport.New(width-offsetWindowWidth, height-offsetWindowHeight)
+// NewPortfolioModel creates a model styled for the server's own terminal, as
+// used by -local. Remote sessions use NewPortfolioModelWithRenderer.
+func NewPortfolioModel(width, height int, config *ServerConfig) *PortfolioModel {
+	return NewPortfolioModelWithRenderer(width, height, config, lipgloss.DefaultRenderer())
+}
+
+// NewPortfolioModelWithRenderer creates a model that styles its output with
+// the given renderer instead of the default one
+func NewPortfolioModelWithRenderer(width, height int, config *ServerConfig, renderer *lipgloss.Renderer) *PortfolioModel {
+	vp := viewport.New(max(width-offsetWindowWidth, 0), max(height-offsetWindowHeight, 0))
+
+	sections := config.Sections
+	if len(sections) == 0 {
+		sections = navigationSections
+	}
+	theme := config.defaultTheme(config.DataLoader.Snapshot())
 
 	model := &PortfolioModel{
-		sections: []Section{
-			AboutSection,
-			ExperienceSection,
-			SkillsSection,
-			ContactSection,
-			// Help section excluded from normal navigation
-		},
-		currentSection: AboutSection,
+		sections:       sections,
+		currentSection: sections[0],
 		viewport:       vp,
 		width:          width,
 		height:         height,
-		styles:         NewPortfolioStyles(),
+		styles:         NewPortfolioStylesWithRenderer(renderer, theme),
+		theme:          theme,
+		themes:         config.availableThemes(),
+		renderer:       renderer,
 		animationTick:  0,
 		particles:      make([]Particle, 0),
-		effectsEnabled: true,
+		effectsEnabled: config.Effects,
 		startTime:      time.Now(),
-		dataLoader:     dataLoader,
+		limits:         config.Limiter.Limits(),
+		lastInput:      time.Now(),
+		dataLoader:     config.DataLoader,
+		stats:          config.Stats,
+		programs:       config.Programs,
+		metrics:        config.Metrics,
+		logger:         log.Default(),
 	}
 
+	model.setAccessible(config.Accessible)
 	model.updateContent()
 	return model
 }
 
 func (m *PortfolioModel) Init() tea.Cmd {
+	m.sectionOpened()
 	return tea.Tick(time.Millisecond*50, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
@@ -97,55 +211,106 @@ func (m *PortfolioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.viewport.Width = msg.Width - offsetWindowWidth
-		m.viewport.Height = msg.Height - offsetWindowHeight
+		m.viewport.Width = max(msg.Width-offsetWindowWidth, 0)
+		m.viewport.Height = max(msg.Height-offsetWindowHeight, 0)
 		m.updateContent()
 		if !m.ready {
 			m.ready = true
 		}
 
+	case dataReloadedMsg:
+		m.refreshContent()
+		return m, nil
+
+	case backgroundMsg:
+		m.setBackground(msg.dark)
+		return m, nil
+
 	case tickMsg:
-		if m.effectsEnabled {
+		tick := tea.Tick(time.Millisecond*50, func(t time.Time) tea.Msg {
+			return tickMsg(t)
+		})
+
+		// Say goodbye, then disconnect, once a session limit is reached
+		if m.goodbye == "" {
+			if reason, message := m.limitReached(); reason != "" {
+				m.goodbye = message
+				m.analytics.SetEndReason(reason)
+				return m, tea.Batch(tick, tea.Tick(goodbyeDelay, func(time.Time) tea.Msg {
+					return tea.QuitMsg{}
+				}))
+			}
+		}
+
+		if m.animated() {
 			m.animationTick++
 			m.updateParticles()
 		}
 
-		// Update content for sections with real-time data (About section)
-		if m.currentSection == AboutSection {
-			m.updateContent()
+		// Update content for sections with real-time data (About and Live
+		// sections). In accessible mode the text holds still, so screen
+		// readers don't announce every second.
+		if !m.accessible && (m.currentSection == AboutSection || m.currentSection == LiveSection) {
+			m.refreshContent()
 		}
 
-		return m, tea.Tick(time.Millisecond*50, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		})
+		return m, tick
+
+	case tea.MouseMsg:
+		m.lastInput = time.Now()
 
 	case tea.KeyMsg:
+		m.lastInput = time.Now()
+		if m.goodbye != "" && !key.Matches(msg, DefaultKeyMap().Quit) {
+			return m, nil
+		}
+
 		switch {
 		case key.Matches(msg, DefaultKeyMap().Quit):
+			m.analytics.SetEndReason(endQuit)
 			return m, tea.Quit
 		case key.Matches(msg, DefaultKeyMap().Help):
 			// Toggle help section - special navigation
 			if m.currentSection == HelpSection {
-				m.currentSection = AboutSection // Return to About when leaving help
+				m.currentSection = m.sections[0] // Return to the first tab when leaving help
 			} else {
 				m.currentSection = HelpSection
 			}
+			m.sectionOpened()
 			m.updateContent()
 			return m, nil
 		case msg.String() == "e":
 			m.effectsEnabled = !m.effectsEnabled
 			return m, nil
+		case key.Matches(msg, DefaultKeyMap().Theme):
+			m.themeChosen = true
+			m.setTheme(nextTheme(m.themes, m.theme))
+			return m, nil
+		case key.Matches(msg, DefaultKeyMap().Accessible):
+			m.setAccessible(!m.accessible)
+			return m, nil
+		case key.Matches(msg, DefaultKeyMap().Background):
+			// Override the detected background with the theme's other variant
+			m.themeChosen = true
+			m.setTheme(m.theme.variantFor(m.themes, !m.theme.Dark))
+			return m, nil
 		case msg.String() == "r":
-			// Reload data (useful for development)
-			if err := m.dataLoader.ReloadData(); err != nil {
-				log.Printf("Failed to reload data: %v", err)
+			// Reload data (useful for development) and refresh every session
+			err := m.dataLoader.ReloadData()
+			m.metrics.DataReloaded(err)
+			if err != nil {
+				m.logger.Error("Failed to reload data", "err", err)
 			} else {
 				m.updateContent()
-				log.Printf("Data reloaded successfully")
+				m.programs.Broadcast(dataReloadedMsg{})
+				m.logger.Info("Data reloaded", "path", m.dataLoader.dataPath)
 			}
 			return m, nil
 		case msg.String() == "x":
-			m.addExplosion(m.viewport.Width/2, m.viewport.Height/2)
+			if m.animated() {
+				m.addExplosion(m.viewport.Width/2, m.viewport.Height/2)
+				m.metrics.ExplosionTriggered()
+			}
 			return m, nil
 		case key.Matches(msg, DefaultKeyMap().Next):
 			// Only navigate through normal sections, not help
@@ -179,29 +344,14 @@ func (m *PortfolioModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	m.viewport, cmd = m.viewport.Update(msg)
+	m.analytics.Scrolled(m.scrollDepth())
 	return m, cmd
 }
 
 func (m *PortfolioModel) addExplosion(x, y int) {
 	chars := []string{"*", "★", "✦", "✧", "●", "◉", "◎", "○", "◯", "◦", "•", "+", "×", "▪", "▫"}
 
-	// Catppuccin Mocha explosion colors
-	colors := []lipgloss.Color{
-		"#f5c2e7", // Pink
-		"#cba6f7", // Mauve
-		"#b4befe", // Lavender
-		"#89b4fa", // Blue
-		"#74c7ec", // Sapphire
-		"#89dceb", // Sky
-		"#94e2d5", // Teal
-		"#a6e3a1", // Green
-		"#f9e2af", // Yellow
-		"#fab387", // Peach
-		"#eba0ac", // Maroon
-		"#f38ba8", // Red
-		"#f2cdcd", // Flamingo
-		"#f5e0dc", // Rosewater
-	}
+	colors := m.theme.explosionColors()
 
 	// Create explosion particles
 	particleCount := 20 + rand.Intn(10)
@@ -265,7 +415,7 @@ func (m *PortfolioModel) renderParticles() string {
 	for _, p := range m.particles {
 		x, y := int(p.x), int(p.y)
 		if x >= 0 && x < width && y >= 0 && y < height {
-			style := lipgloss.NewStyle().Foreground(p.color)
+			style := m.renderer.NewStyle().Foreground(p.color)
 
 			// Fade out based on life
 			if p.life < 0.3 {
@@ -337,20 +487,29 @@ func (m *PortfolioModel) combineLine(base, overlay string) string {
 }
 
 func (m *PortfolioModel) View() string {
+	defer m.metrics.ViewRendered(time.Now())
+
 	if !m.ready {
 		return m.renderLoadingScreen()
 	}
+	if m.goodbye != "" {
+		return m.renderGoodbye()
+	}
 
 	var content strings.Builder
 
 	// Navigation tabs
 	content.WriteString("\n")
-	content.WriteString(m.renderTabs())
+	if m.accessible {
+		content.WriteString(m.renderAccessibleTabs())
+	} else {
+		content.WriteString(m.renderTabs())
+	}
 	content.WriteString("\n")
 
 	// Main content area with particle overlay
 	mainContent := m.viewport.View()
-	if len(m.particles) > 0 && m.effectsEnabled {
+	if len(m.particles) > 0 && m.animated() {
 		mainContent = m.overlayParticles(mainContent)
 	}
 
@@ -358,12 +517,20 @@ func (m *PortfolioModel) View() string {
 	content.WriteString("\n")
 
 	// Footer - simplified since help is now a tab
-	content.WriteString(m.renderFooter())
+	if m.accessible {
+		content.WriteString(m.renderAccessibleFooter())
+	} else {
+		content.WriteString(m.renderFooter())
+	}
 
 	return content.String()
 }
 
 func (m *PortfolioModel) renderLoadingScreen() string {
+	if m.accessible {
+		return "Loading the portfolio, please wait..."
+	}
+
 	loadingFrames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 	frame := loadingFrames[m.animationTick%len(loadingFrames)]
 
@@ -380,30 +547,41 @@ func (m *PortfolioModel) renderLoadingScreen() string {
 	return m.styles.Header.Render(loading)
 }
 
-func (m *PortfolioModel) renderTabs() string {
-	var leftTabs []string
-	var rightTabs []string
-
-	sectionNames := map[Section]string{
-		AboutSection:      "About",
-		ExperienceSection: "Experience",
-		SkillsSection:     "Skills",
-		ContactSection:    "Contact",
-		HelpSection:       "Help",
+// limitReached returns which limit ended the session and the message to show,
+// or empty strings while the session is within its limits
+func (m *PortfolioModel) limitReached() (reason, message string) {
+	switch {
+	case m.limits.IdleTimeout > 0 && time.Since(m.lastInput) >= m.limits.IdleTimeout:
+		return endIdle, fmt.Sprintf("No input for %s, so this session is closing to make room for other visitors.", formatDuration(m.limits.IdleTimeout))
+	case m.limits.MaxDuration > 0 && time.Since(m.startTime) >= m.limits.MaxDuration:
+		return endMaxDuration, fmt.Sprintf("Sessions are limited to %s, and yours is up.", formatDuration(m.limits.MaxDuration))
+	default:
+		return "", ""
 	}
+}
 
-	icons := map[Section]string{
-		AboutSection:      "👋",
-		ExperienceSection: "💼",
-		SkillsSection:     "🚀",
-		ContactSection:    "📞",
-		HelpSection:       "❓",
+func (m *PortfolioModel) renderGoodbye() string {
+	goodbye := fmt.Sprintf(`
+    👋 Thanks for stopping by!
+
+    %s
+    Reconnect any time.
+    `, m.goodbye)
+
+	if m.accessible {
+		return linearText(goodbye)
 	}
+	return m.renderer.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.styles.Header.Render(goodbye))
+}
+
+func (m *PortfolioModel) renderTabs() string {
+	var leftTabs []string
+	var rightTabs []string
 
 	// Render normal navigation tabs on the left
 	for _, section := range m.sections {
-		name := sectionNames[section]
-		tabText := icons[section] + " " + name
+		name := sectionTitles[section]
+		tabText := sectionIcons[section] + " " + name
 
 		if section == m.currentSection {
 			leftTabs = append(leftTabs, m.styles.ActiveTab.Render(tabText))
@@ -413,7 +591,7 @@ func (m *PortfolioModel) renderTabs() string {
 	}
 
 	// Render help tab on the right
-	helpTabText := icons[HelpSection] + " " + sectionNames[HelpSection]
+	helpTabText := sectionIcons[HelpSection] + " " + sectionTitles[HelpSection]
 	if m.currentSection == HelpSection {
 		rightTabs = append(rightTabs, m.styles.ActiveTab.Render(helpTabText))
 	} else {
@@ -429,7 +607,7 @@ func (m *PortfolioModel) renderTabs() string {
 }
 
 func (m *PortfolioModel) renderFooter() string {
-	help := "Tab/Shift+Tab: Navigate • ?: Help • e: Toggle Effects • r: Reload Data • x: Explosion • q: Quit"
+	help := "Tab/Shift+Tab: Navigate • ?: Help • a: Accessible • t: Theme • b: Light/Dark • e: Toggle Effects • r: Reload Data • x: Explosion • q: Quit"
 
 	status := ("💻 Portfolio on Interactive Terminal 🎮")
 
@@ -444,40 +622,102 @@ func (m *PortfolioModel) renderFooter() string {
 }
 
 func (m *PortfolioModel) nextSection() {
-	current := int(m.currentSection)
-	m.currentSection = Section((current + 1) % len(m.sections))
+	current := slices.Index(m.sections, m.currentSection)
+	m.currentSection = m.sections[(current+1)%len(m.sections)]
+	m.sectionOpened()
 }
 
 func (m *PortfolioModel) prevSection() {
-	current := int(m.currentSection)
-	if current == 0 {
-		m.currentSection = Section(len(m.sections) - 1)
+	current := slices.Index(m.sections, m.currentSection)
+	if current <= 0 {
+		m.currentSection = m.sections[len(m.sections)-1]
 	} else {
-		m.currentSection = Section(current - 1)
+		m.currentSection = m.sections[current-1]
 	}
+	m.sectionOpened()
 }
 
 func (m *PortfolioModel) updateContent() {
+	// Render from a single snapshot so a concurrent reload can't mix old and new data
+	m.data = m.dataLoader.Snapshot()
 	content := m.getSectionContent(m.currentSection)
 	m.viewport.SetContent(content)
 	m.viewport.GotoTop()
+	m.analytics.Scrolled(m.scrollDepth())
+}
+
+// setTheme restyles the session with a theme, keeping the scroll position
+func (m *PortfolioModel) setTheme(theme *Theme) {
+	m.theme = theme
+	m.styles = NewPortfolioStylesWithRenderer(m.renderer, theme)
+	if m.accessible {
+		linearStyles(m.styles)
+	}
+
+	m.refreshContent()
+}
+
+// refreshContent re-renders the current section, keeping the scroll position
+func (m *PortfolioModel) refreshContent() {
+	offset := m.viewport.YOffset
+	m.updateContent()
+	m.viewport.SetYOffset(offset)
+}
+
+// setBackground switches to the variant of the theme that suits the
+// terminal's background, unless the visitor has picked a theme already
+func (m *PortfolioModel) setBackground(dark bool) {
+	m.renderer.SetHasDarkBackground(dark)
+	if m.themeChosen {
+		return
+	}
+	if theme := m.theme.variantFor(m.themes, dark); theme != m.theme {
+		m.setTheme(theme)
+	}
+}
+
+// scrollDepth returns how much of the current section has been on screen,
+// from 0 to 1
+func (m *PortfolioModel) scrollDepth() float64 {
+	total := m.viewport.TotalLineCount()
+	if total == 0 {
+		return 1
+	}
+	return min(float64(m.viewport.YOffset+m.viewport.Height)/float64(total), 1)
+}
+
+// sectionOpened records the current section being opened
+func (m *PortfolioModel) sectionOpened() {
+	m.metrics.SectionViewed(m.currentSection)
+	m.analytics.EnterSection(m.currentSection)
 }
 
 func (m *PortfolioModel) getSectionContent(section Section) string {
+	var content string
 	switch section {
 	case AboutSection:
-		return m.renderAbout()
+		content = m.renderAbout()
 	case ExperienceSection:
-		return m.renderExperience()
+		content = m.renderExperience()
 	case SkillsSection:
-		return m.renderSkills()
+		content = m.renderSkills()
+	case ProjectsSection:
+		content = m.renderProjects()
 	case ContactSection:
-		return m.renderContact()
+		content = m.renderContact()
+	case LiveSection:
+		content = m.renderLive()
 	case HelpSection:
-		return m.renderHelp()
+		content = m.renderHelp()
 	default:
-		return "Section not found"
+		content = "Section not found"
+	}
+
+	// Emoji read out as their names, which is noise between every heading
+	if m.accessible {
+		content = linearText(content)
 	}
+	return content
 }
 
 func (m *PortfolioModel) renderHelp() string {
@@ -492,19 +732,20 @@ func (m *PortfolioModel) renderHelp() string {
 🎬 Effects:
   e                Toggle particle effects on/off
   x                Trigger explosion at center
+  t                Switch to the next color theme
+  b                Switch between the light and dark variant of the theme
+  a                Toggle accessible mode: no emoji or motion, high contrast, plain layout
 
 📋 Sections:
-  👋 About         Personal introduction, current time, and tech facts
-  💼 Experience    Professional work history
-  🚀 Skills        Technical expertise and proficiency
-  📞 Contact       Get in touch information
-
+` + m.renderHelpSections() + `
 💡 Tips:
   • Press '?' anytime to access help
   • This runs entirely in your terminal!
 
 🌈 Theme:
-  • Using beautiful Catppuccin Mocha color palette
+  • Using the ` + m.theme.Title + ` color palette
+  • Press 't' to cycle through all ` + fmt.Sprint(len(m.themes)) + ` themes
+  • The light or dark variant is picked to match your terminal; press 'b' to swap
 
 🚀 Getting Started:
   • Use Tab/Shift+Tab | h/l to navigate between main sections
@@ -515,3 +756,12 @@ func (m *PortfolioModel) renderHelp() string {
 
 	return m.styles.ContentText.Render(help)
 }
+
+// renderHelpSections lists the enabled sections, in tab order
+func (m *PortfolioModel) renderHelpSections() string {
+	var b strings.Builder
+	for _, section := range m.sections {
+		fmt.Fprintf(&b, "  %s %-13s %s\n", sectionIcons[section], sectionTitles[section], sectionDescriptions[section])
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTickKeepsScrollPosition(t *testing.T) {
+	config := &ServerConfig{DataLoader: newTestLoader(t), Programs: NewProgramRegistry(), Effects: true}
+	m := NewPortfolioModel(80, 12, config)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 12})
+
+	for _, section := range []Section{AboutSection, LiveSection} {
+		m.currentSection = section
+		m.updateContent()
+		if m.viewport.TotalLineCount() <= m.viewport.Height+2 {
+			t.Fatalf("section %d fits on screen, nothing to scroll", section)
+		}
+
+		m.viewport.SetYOffset(2)
+		m.Update(tickMsg(time.Now()))
+		if m.viewport.YOffset != 2 {
+			t.Errorf("section %d scrolled to %d after a tick, want 2", section, m.viewport.YOffset)
+		}
+		m.Update(dataReloadedMsg{})
+		if m.viewport.YOffset != 2 {
+			t.Errorf("section %d scrolled to %d after a reload, want 2", section, m.viewport.YOffset)
+		}
+
+		// Changing section starts at the top
+		m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		if m.viewport.YOffset != 0 {
+			t.Errorf("next section opened at %d, want the top", m.viewport.YOffset)
+		}
+	}
+}
+
+func TestHelpListsEnabledSections(t *testing.T) {
+	config := &ServerConfig{
+		DataLoader: newTestLoader(t),
+		Programs:   NewProgramRegistry(),
+		Sections:   []Section{ProjectsSection, AboutSection},
+	}
+	m := NewPortfolioModel(80, 24, config)
+
+	help := m.renderHelpSections()
+	projects := strings.Index(help, sectionTitles[ProjectsSection])
+	about := strings.Index(help, sectionTitles[AboutSection])
+	if projects < 0 || about < projects {
+		t.Errorf("sections listed out of tab order:\n%s", help)
+	}
+	for _, section := range []Section{ExperienceSection, SkillsSection, ContactSection, LiveSection} {
+		if strings.Contains(help, sectionTitles[section]) {
+			t.Errorf("disabled section %s listed:\n%s", sectionTitles[section], help)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ProgramRegistry keeps track of the running tea programs, one per session
+type ProgramRegistry struct {
+	mu       sync.Mutex
+	programs map[*tea.Program]struct{}
+}
+
+// NewProgramRegistry creates an empty program registry
+func NewProgramRegistry() *ProgramRegistry {
+	return &ProgramRegistry{
+		programs: make(map[*tea.Program]struct{}),
+	}
+}
+
+// Register adds a running program to the registry
+func (r *ProgramRegistry) Register(p *tea.Program) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.programs[p] = struct{}{}
+}
+
+// Unregister removes a finished program from the registry
+func (r *ProgramRegistry) Unregister(p *tea.Program) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.programs, p)
+}
+
+// Broadcast sends a message to every registered program. Messages are sent
+// asynchronously so it is safe to call from within a model's Update.
+func (r *ProgramRegistry) Broadcast(msg tea.Msg) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for p := range r.programs {
+		go p.Send(msg)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+// processStartTime records when the server process started
+var processStartTime = time.Now()
+
+// ServerStats tracks live statistics about the running server
+type ServerStats struct {
+	activeSessions atomic.Int64
+	totalSessions  atomic.Int64
+}
+
+// NewServerStats creates a new server statistics tracker
+func NewServerStats() *ServerStats {
+	return &ServerStats{}
+}
+
+// Middleware counts SSH sessions as they start and end
+func (s *ServerStats) Middleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(sess ssh.Session) {
+			s.SessionStarted()
+			defer s.SessionEnded()
+			next(sess)
+		}
+	}
+}
+
+// SessionStarted records a newly connected session
+func (s *ServerStats) SessionStarted() {
+	s.activeSessions.Add(1)
+	s.totalSessions.Add(1)
+}
+
+// SessionEnded records a disconnected session
+func (s *ServerStats) SessionEnded() {
+	s.activeSessions.Add(-1)
+}
+
+// Uptime returns how long the server process has been running
+func (s *ServerStats) Uptime() time.Duration {
+	return time.Since(processStartTime)
+}
+
+// ActiveSessions returns the number of currently connected sessions
+func (s *ServerStats) ActiveSessions() int64 {
+	return s.activeSessions.Load()
+}
+
+// TotalSessions returns the number of sessions served since startup
+func (s *ServerStats) TotalSessions() int64 {
+	return s.totalSessions.Load()
+}
@@ -27,42 +27,50 @@ type PortfolioStyles struct {
 	StatsBox           lipgloss.Style
 	FactBox            lipgloss.Style
 	AsciiArt           lipgloss.Style
+	MarkdownHeading    lipgloss.Style
+	MarkdownBold       lipgloss.Style
+	MarkdownItalic     lipgloss.Style
+	MarkdownCode       lipgloss.Style
+	MarkdownCodeBlock  lipgloss.Style
+	MarkdownLink       lipgloss.Style
+	MarkdownBullet     lipgloss.Style
 }
 
-func NewPortfolioStyles() *PortfolioStyles {
-	// Catppuccin Mocha color palette
+// NewPortfolioStyles creates the styles for a theme with the default
+// renderer, which targets the server's own terminal
+func NewPortfolioStyles(theme *Theme) *PortfolioStyles {
+	return NewPortfolioStylesWithRenderer(lipgloss.DefaultRenderer(), theme)
+}
+
+// NewPortfolioStylesWithRenderer creates the styles for a theme and a
+// specific renderer, so output matches the color support of the terminal it
+// is sent to
+func NewPortfolioStylesWithRenderer(r *lipgloss.Renderer, theme *Theme) *PortfolioStyles {
 	var (
-		// Base colors
-		base   = lipgloss.Color("#1e1e2e") // Dark background
-		mantle = lipgloss.Color("#181825") // Darker background
-
-		// Surface colors
-		surface0 = lipgloss.Color("#313244") // Light surface
-		surface1 = lipgloss.Color("#45475a") // Medium surface
-
-		// Text colors
-		text     = lipgloss.Color("#cdd6f4") // Main text
-		subtext1 = lipgloss.Color("#bac2de") // Secondary text
-		subtext0 = lipgloss.Color("#a6adc8") // Muted text
-		overlay2 = lipgloss.Color("#9399b2") // Overlays
-		overlay1 = lipgloss.Color("#7f849c") // Darker overlays
-
-		// Accent colors
-		lavender = lipgloss.Color("#b4befe") // Light purple
-		blue     = lipgloss.Color("#89b4fa") // Blue
-		sapphire = lipgloss.Color("#74c7ec") // Light blue
-		sky      = lipgloss.Color("#89dceb") // Sky blue
-		teal     = lipgloss.Color("#94e2d5") // Teal
-		green    = lipgloss.Color("#a6e3a1") // Green
-		yellow   = lipgloss.Color("#f9e2af") // Yellow
-		peach    = lipgloss.Color("#fab387") // Orange
-		mauve    = lipgloss.Color("#cba6f7") // Purple
-		pink     = lipgloss.Color("#f5c2e7") // Pink
-		flamingo = lipgloss.Color("#f2cdcd") // Light pink
+		base     = theme.Palette.Base
+		mantle   = theme.Palette.Mantle
+		surface0 = theme.Palette.Surface0
+		surface1 = theme.Palette.Surface1
+		text     = theme.Palette.Text
+		subtext1 = theme.Palette.Subtext1
+		subtext0 = theme.Palette.Subtext0
+		overlay2 = theme.Palette.Overlay2
+		overlay1 = theme.Palette.Overlay1
+		lavender = theme.Palette.Lavender
+		blue     = theme.Palette.Blue
+		sapphire = theme.Palette.Sapphire
+		sky      = theme.Palette.Sky
+		teal     = theme.Palette.Teal
+		green    = theme.Palette.Green
+		yellow   = theme.Palette.Yellow
+		peach    = theme.Palette.Peach
+		mauve    = theme.Palette.Mauve
+		pink     = theme.Palette.Pink
+		flamingo = theme.Palette.Flamingo
 	)
 
-	return &PortfolioStyles{
-		Header: lipgloss.NewStyle().
+	styles := &PortfolioStyles{
+		Header: r.NewStyle().
 			Bold(true).
 			Foreground(text).
 			Background(base).
@@ -72,43 +80,43 @@ func NewPortfolioStyles() *PortfolioStyles {
 			BorderForeground(lavender).
 			Align(lipgloss.Center),
 
-		Subtitle: lipgloss.NewStyle().
+		Subtitle: r.NewStyle().
 			Foreground(subtext1).
 			Italic(true).
 			Align(lipgloss.Center),
 
-		ActiveTab: lipgloss.NewStyle().
+		ActiveTab: r.NewStyle().
 			Bold(true).
 			Foreground(base).
 			Background(mauve).
 			Padding(0, 2).
 			MarginRight(1),
 
-		InactiveTab: lipgloss.NewStyle().
+		InactiveTab: r.NewStyle().
 			Foreground(overlay1).
 			Background(surface0).
 			Padding(0, 2).
 			MarginRight(1),
 
-		ContentBox: lipgloss.NewStyle().
+		ContentBox: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(surface1).
 			Padding(1, 2).
 			MarginTop(1).
 			MarginBottom(1),
 
-		FooterLeft: lipgloss.NewStyle().
+		FooterLeft: r.NewStyle().
 			Foreground(overlay2).
 			Background(surface0).
 			Padding(0, 1),
 
-		FooterRight: lipgloss.NewStyle().
+		FooterRight: r.NewStyle().
 			Foreground(sapphire).
 			Background(surface0).
 			Padding(0, 1).
 			Bold(true),
 
-		HelpBox: lipgloss.NewStyle().
+		HelpBox: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(yellow).
 			Background(base).
@@ -116,7 +124,7 @@ func NewPortfolioStyles() *PortfolioStyles {
 			Padding(1, 2).
 			MarginTop(1),
 
-		SectionTitle: lipgloss.NewStyle().
+		SectionTitle: r.NewStyle().
 			Bold(true).
 			Foreground(mauve).
 			BorderStyle(lipgloss.NormalBorder()).
@@ -125,11 +133,11 @@ func NewPortfolioStyles() *PortfolioStyles {
 			PaddingBottom(1).
 			MarginBottom(1),
 
-		ContentText: lipgloss.NewStyle().
+		ContentText: r.NewStyle().
 			Foreground(text).
 			MarginBottom(1),
 
-		ExperienceTitle: lipgloss.NewStyle().
+		ExperienceTitle: r.NewStyle().
 			Bold(true).
 			Foreground(blue).
 			Background(surface0).
@@ -137,15 +145,15 @@ func NewPortfolioStyles() *PortfolioStyles {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(blue),
 
-		ExperienceMeta: lipgloss.NewStyle().
+		ExperienceMeta: r.NewStyle().
 			Foreground(subtext0).
 			Italic(true),
 
-		ExperienceDetail: lipgloss.NewStyle().
+		ExperienceDetail: r.NewStyle().
 			Foreground(text).
 			MarginLeft(2),
 
-		SkillCategory: lipgloss.NewStyle().
+		SkillCategory: r.NewStyle().
 			Bold(true).
 			Foreground(teal).
 			MarginBottom(1).
@@ -153,10 +161,10 @@ func NewPortfolioStyles() *PortfolioStyles {
 			BorderForeground(teal).
 			PaddingBottom(1),
 
-		SkillBar: lipgloss.NewStyle().
+		SkillBar: r.NewStyle().
 			Foreground(green),
 
-		ProjectTitle: lipgloss.NewStyle().
+		ProjectTitle: r.NewStyle().
 			Bold(true).
 			Foreground(peach).
 			Background(surface0).
@@ -165,15 +173,15 @@ func NewPortfolioStyles() *PortfolioStyles {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(peach),
 
-		ProjectDescription: lipgloss.NewStyle().
+		ProjectDescription: r.NewStyle().
 			Foreground(subtext1).
 			Italic(true),
 
-		ProjectLabel: lipgloss.NewStyle().
+		ProjectLabel: r.NewStyle().
 			Bold(true).
 			Foreground(sky),
 
-		LiveTitle: lipgloss.NewStyle().
+		LiveTitle: r.NewStyle().
 			Bold(true).
 			Foreground(sapphire).
 			Background(surface0).
@@ -181,7 +189,7 @@ func NewPortfolioStyles() *PortfolioStyles {
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(sapphire),
 
-		LiveValue: lipgloss.NewStyle().
+		LiveValue: r.NewStyle().
 			Bold(true).
 			Foreground(yellow).
 			Background(mantle).
@@ -190,19 +198,19 @@ func NewPortfolioStyles() *PortfolioStyles {
 			BorderForeground(yellow).
 			Align(lipgloss.Center),
 
-		LiveSubtitle: lipgloss.NewStyle().
+		LiveSubtitle: r.NewStyle().
 			Foreground(subtext0).
 			Italic(true).
 			Align(lipgloss.Center),
 
-		StatsBox: lipgloss.NewStyle().
+		StatsBox: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(green).
 			Background(surface0).
 			Foreground(text).
 			Padding(1, 2),
 
-		FactBox: lipgloss.NewStyle().
+		FactBox: r.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(pink).
 			Background(surface0).
@@ -210,8 +218,36 @@ func NewPortfolioStyles() *PortfolioStyles {
 			Padding(1, 2).
 			Italic(true),
 
-		AsciiArt: lipgloss.NewStyle().
+		AsciiArt: r.NewStyle().
 			Foreground(flamingo).
 			Align(lipgloss.Center),
+
+		MarkdownHeading: r.NewStyle().
+			Bold(true).
+			Foreground(mauve),
+
+		MarkdownBold: r.NewStyle().
+			Bold(true),
+
+		MarkdownItalic: r.NewStyle().
+			Italic(true),
+
+		MarkdownCode: r.NewStyle().
+			Foreground(peach).
+			Background(surface0),
+
+		MarkdownCodeBlock: r.NewStyle().
+			Foreground(text).
+			Background(mantle).
+			Padding(0, 1),
+
+		MarkdownLink: r.NewStyle().
+			Foreground(blue).
+			Underline(true),
+
+		MarkdownBullet: r.NewStyle().
+			Foreground(lavender),
 	}
+	theme.applyOverrides(styles)
+	return styles
 }
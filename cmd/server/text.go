@@ -0,0 +1,73 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	textDefaultWidth = 80
+	textMinWidth     = 40
+)
+
+// textSections are the sections available as non-interactive text, in page
+// order, keyed by their URL path and SSH command name
+var textSections = []struct {
+	name        string
+	section     Section
+	description string
+}{
+	{"about", AboutSection, "Who I am and what I do"},
+	{"experience", ExperienceSection, "Professional experience"},
+	{"skills", SkillsSection, "Technical skills"},
+	{"projects", ProjectsSection, "Projects I've built"},
+	{"contact", ContactSection, "How to reach me"},
+}
+
+// lookupTextSection finds an enabled text section by name
+func lookupTextSection(config *ServerConfig, name string) (Section, bool) {
+	for _, s := range textSections {
+		if s.name == strings.ToLower(name) && config.sectionEnabled(s.section) {
+			return s.section, true
+		}
+	}
+	return 0, false
+}
+
+// allTextSections returns every enabled text section in page order
+func allTextSections(config *ServerConfig) []Section {
+	sections := make([]Section, 0, len(textSections))
+	for _, s := range textSections {
+		if config.sectionEnabled(s.section) {
+			sections = append(sections, s.section)
+		}
+	}
+	return sections
+}
+
+// renderText renders sections with the same renderers as the TUI, for output
+// that isn't interactive, in accessible mode if asked. The footer, if any, is
+// added in a muted style.
+func renderText(config *ServerConfig, sections []Section, width int, renderer *lipgloss.Renderer, accessible bool, footer string) string {
+	width = max(width, textMinWidth)
+
+	m := NewPortfolioModelWithRenderer(width+offsetWindowWidth, fallbackHeight+offsetWindowHeight, config, renderer)
+	m.effectsEnabled = false
+	m.setAccessible(accessible)
+
+	var out strings.Builder
+	for i, section := range sections {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(m.getSectionContent(section))
+		out.WriteString("\n")
+	}
+
+	if footer != "" {
+		out.WriteString("\n" + m.styles.LiveSubtitle.Align(lipgloss.Left).Render(footer) + "\n")
+	}
+
+	return out.String()
+}
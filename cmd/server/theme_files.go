@@ -0,0 +1,411 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ThemeFile is the format of a user theme file. Colors are hex (#89b4fa) or
+// ANSI color numbers (0-255); colors in styles may also name a palette color.
+//
+//	name = "solarized-dark"
+//	extends = "catppuccin-mocha"
+//	variant = "solarized-light"
+//
+//	[palette]
+//	base = "#002b36"
+//	text = "#839496"
+//
+//	[styles.active_tab]
+//	background = "yellow"
+//	padding = [0, 3]
+type ThemeFile struct {
+	Name    string                   `json:"name" yaml:"name" toml:"name"`          // Defaults to the file name
+	Title   string                   `json:"title" yaml:"title" toml:"title"`       // Defaults to the name
+	Dark    *bool                    `json:"dark" yaml:"dark" toml:"dark"`          // Defaults to the extended theme's, or true
+	Extends string                   `json:"extends" yaml:"extends" toml:"extends"` // Built-in theme supplying the colors left out
+	Variant string                   `json:"variant" yaml:"variant" toml:"variant"` // Theme to use on the opposite background
+	Palette map[string]string        `json:"palette" yaml:"palette" toml:"palette"`
+	Styles  map[string]StyleOverride `json:"styles" yaml:"styles" toml:"styles"` // Keyed by style, e.g. active_tab
+}
+
+// StyleOverride changes parts of one of the PortfolioStyles. Unset fields
+// keep the theme's styling.
+type StyleOverride struct {
+	Foreground       string `json:"foreground" yaml:"foreground" toml:"foreground"`
+	Background       string `json:"background" yaml:"background" toml:"background"`
+	Border           string `json:"border" yaml:"border" toml:"border"`                   // normal, rounded, thick, double, block, ascii, hidden or none
+	BorderSides      []bool `json:"border_sides" yaml:"border_sides" toml:"border_sides"` // top, right, bottom, left, shortened as in CSS
+	BorderForeground string `json:"border_foreground" yaml:"border_foreground" toml:"border_foreground"`
+	Padding          []int  `json:"padding" yaml:"padding" toml:"padding"` // top, right, bottom, left, shortened as in CSS
+	Margin           []int  `json:"margin" yaml:"margin" toml:"margin"`
+	Bold             *bool  `json:"bold" yaml:"bold" toml:"bold"`
+	Italic           *bool  `json:"italic" yaml:"italic" toml:"italic"`
+	Underline        *bool  `json:"underline" yaml:"underline" toml:"underline"`
+	Faint            *bool  `json:"faint" yaml:"faint" toml:"faint"`
+	Align            string `json:"align" yaml:"align" toml:"align"` // left, center or right
+}
+
+// themeBorders are the borders theme files can pick, by name
+var themeBorders = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"block":   lipgloss.BlockBorder(),
+	"ascii":   lipgloss.ASCIIBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// themeAlignments are the alignments theme files can pick, by name
+var themeAlignments = map[string]lipgloss.Position{
+	"left":   lipgloss.Left,
+	"center": lipgloss.Center,
+	"right":  lipgloss.Right,
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// LoadThemes reads every theme file (.json, .toml, .yaml or .yml) in dir, in
+// file name order. Every problem in every file is reported. An empty dir
+// loads nothing.
+func LoadThemes(dir string) ([]*Theme, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read themes directory: %w", err)
+	}
+
+	var (
+		loaded []*Theme
+		paths  []string
+		errs   []error
+	)
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".toml", ".yaml", ".yml":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		theme, err := loadThemeFile(path)
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			// Put the path on every problem, so each reads on its own
+			for _, err := range joined.Unwrap() {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			}
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if _, ok := findTheme(slices.Concat(builtinThemes, loaded), theme.Name); ok {
+			errs = append(errs, fmt.Errorf("%s: theme %q is already defined", path, theme.Name))
+			continue
+		}
+		loaded = append(loaded, theme)
+		paths = append(paths, path)
+	}
+
+	// Variants may name themes from later files, so they're checked last
+	available := slices.Concat(builtinThemes, loaded)
+	for i, theme := range loaded {
+		if theme.Variant == "" {
+			continue
+		}
+		if _, ok := findTheme(available, theme.Variant); !ok {
+			errs = append(errs, fmt.Errorf("%s: variant: unknown theme %q (expected %s)",
+				paths[i], theme.Variant, strings.Join(themeNames(available), ", ")))
+			theme.Variant = ""
+		}
+	}
+
+	return loaded, errors.Join(errs...)
+}
+
+// loadThemeFile reads and checks a single theme file
+func loadThemeFile(path string) (*Theme, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme file: %w", err)
+	}
+
+	var file ThemeFile
+	if err := decodeSettings(path, raw, &file); err != nil {
+		return nil, err
+	}
+
+	if file.Name == "" {
+		file.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return file.theme()
+}
+
+// theme checks the file and builds the theme it describes
+func (f *ThemeFile) theme() (*Theme, error) {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	theme := &Theme{
+		Name:    strings.ToLower(strings.TrimSpace(f.Name)),
+		Title:   f.Title,
+		Dark:    true,
+		Variant: strings.ToLower(strings.TrimSpace(f.Variant)),
+	}
+	if strings.ContainsFunc(theme.Name, unicode.IsSpace) || strings.Contains(theme.Name, ",") {
+		fail("name %q can't contain spaces or commas", f.Name)
+	}
+	if theme.Title == "" {
+		theme.Title = theme.Name
+	}
+
+	if f.Extends != "" {
+		base, ok := findTheme(builtinThemes, f.Extends)
+		if !ok {
+			fail("extends: unknown theme %q (expected %s)", f.Extends, strings.Join(themeNames(builtinThemes), ", "))
+		} else {
+			theme.Palette = base.Palette
+			theme.Dark = base.Dark
+		}
+	}
+	if f.Dark != nil {
+		theme.Dark = *f.Dark
+	}
+
+	// Palette colors are set by their lowercase field name
+	palette := reflect.ValueOf(&theme.Palette).Elem()
+	roles := paletteRoles()
+	for _, role := range slices.Sorted(maps.Keys(f.Palette)) {
+		value := f.Palette[role]
+		i := slices.Index(roles, role)
+		if i < 0 {
+			fail("palette.%s: unknown color (expected %s)", role, strings.Join(roles, ", "))
+			continue
+		}
+		if !validColor(value) {
+			fail("palette.%s: %q is not a hex color or ANSI color number", role, value)
+			continue
+		}
+		palette.Field(i).SetString(value)
+	}
+	if f.Extends == "" {
+		var missing []string
+		for i, role := range roles {
+			if palette.Field(i).String() == "" {
+				missing = append(missing, role)
+			}
+		}
+		if len(missing) > 0 {
+			fail("palette: missing %s (or set extends to take them from a built-in theme)", strings.Join(missing, ", "))
+		}
+	}
+
+	styleNames := portfolioStyleNames()
+	for _, name := range slices.Sorted(maps.Keys(f.Styles)) {
+		if slices.Index(styleNames, name) < 0 {
+			fail("styles.%s: unknown style (expected %s)", name, strings.Join(styleNames, ", "))
+			continue
+		}
+		for _, err := range f.Styles[name].check(roles) {
+			fail("styles.%s.%v", name, err)
+		}
+	}
+	theme.overrides = f.Styles
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+// check returns the problems in an override, each starting with the field
+func (o StyleOverride) check(roles []string) []error {
+	var errs []error
+	for field, value := range map[string]string{
+		"foreground":        o.Foreground,
+		"background":        o.Background,
+		"border_foreground": o.BorderForeground,
+	} {
+		if value != "" && !validColor(value) && slices.Index(roles, value) < 0 {
+			errs = append(errs, fmt.Errorf("%s: %q is not a hex color, ANSI color number or palette color", field, value))
+		}
+	}
+	if _, ok := themeBorders[o.Border]; o.Border != "" && o.Border != "none" && !ok {
+		errs = append(errs, fmt.Errorf("border: unknown border %q (expected normal, rounded, thick, double, block, ascii, hidden or none)", o.Border))
+	}
+	if o.BorderSides != nil && (len(o.BorderSides) < 1 || len(o.BorderSides) > 4) {
+		errs = append(errs, errors.New("border_sides: expected 1 to 4 values"))
+	}
+	for field, values := range map[string][]int{"padding": o.Padding, "margin": o.Margin} {
+		if values == nil {
+			continue
+		}
+		if len(values) < 1 || len(values) > 4 {
+			errs = append(errs, fmt.Errorf("%s: expected 1 to 4 values", field))
+		}
+		for _, v := range values {
+			if v < 0 {
+				errs = append(errs, fmt.Errorf("%s: %d is negative", field, v))
+			}
+		}
+	}
+	if _, ok := themeAlignments[o.Align]; o.Align != "" && !ok {
+		errs = append(errs, fmt.Errorf("align: unknown alignment %q (expected left, center or right)", o.Align))
+	}
+
+	// Map iteration order is random; keep the report stable
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errs
+}
+
+// apply returns style with the override's changes
+func (o StyleOverride) apply(style lipgloss.Style, palette Palette) lipgloss.Style {
+	if o.Foreground != "" {
+		style = style.Foreground(palette.color(o.Foreground))
+	}
+	if o.Background != "" {
+		style = style.Background(palette.color(o.Background))
+	}
+	switch o.Border {
+	case "":
+	case "none":
+		style = style.UnsetBorderStyle()
+	default:
+		style = style.BorderStyle(themeBorders[o.Border])
+	}
+	if len(o.BorderSides) > 0 {
+		top, right, bottom, left := expandSides(o.BorderSides)
+		style = style.BorderTop(top).BorderRight(right).BorderBottom(bottom).BorderLeft(left)
+	}
+	if o.BorderForeground != "" {
+		style = style.BorderForeground(palette.color(o.BorderForeground))
+	}
+	if len(o.Padding) > 0 {
+		style = style.Padding(o.Padding...)
+	}
+	if len(o.Margin) > 0 {
+		style = style.Margin(o.Margin...)
+	}
+	if o.Bold != nil {
+		style = style.Bold(*o.Bold)
+	}
+	if o.Italic != nil {
+		style = style.Italic(*o.Italic)
+	}
+	if o.Underline != nil {
+		style = style.Underline(*o.Underline)
+	}
+	if o.Faint != nil {
+		style = style.Faint(*o.Faint)
+	}
+	if o.Align != "" {
+		style = style.Align(themeAlignments[o.Align])
+	}
+	return style
+}
+
+// applyOverrides applies a theme's style overrides to styles built from its
+// palette
+func (t *Theme) applyOverrides(styles *PortfolioStyles) {
+	if len(t.overrides) == 0 {
+		return
+	}
+
+	fields := reflect.ValueOf(styles).Elem()
+	for i, name := range portfolioStyleNames() {
+		if override, ok := t.overrides[name]; ok {
+			style := fields.Field(i).Interface().(lipgloss.Style)
+			fields.Field(i).Set(reflect.ValueOf(override.apply(style, t.Palette)))
+		}
+	}
+}
+
+// color resolves a theme file color, which may name a palette color
+func (p Palette) color(value string) lipgloss.Color {
+	if i := slices.Index(paletteRoles(), value); i >= 0 {
+		return lipgloss.Color(reflect.ValueOf(p).Field(i).String())
+	}
+	return lipgloss.Color(value)
+}
+
+// paletteRoles returns the palette color names used in theme files, in field
+// order
+func paletteRoles() []string {
+	t := reflect.TypeOf(Palette{})
+	roles := make([]string, t.NumField())
+	for i := range roles {
+		roles[i] = strings.ToLower(t.Field(i).Name)
+	}
+	return roles
+}
+
+// portfolioStyleNames returns the style names used in theme files, in field
+// order: ActiveTab is active_tab
+func portfolioStyleNames() []string {
+	t := reflect.TypeOf(PortfolioStyles{})
+	names := make([]string, t.NumField())
+	for i := range names {
+		names[i] = snakeCase(t.Field(i).Name)
+	}
+	return names
+}
+
+// snakeCase converts a Go field name to snake_case
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// validColor reports whether value is a hex color or an ANSI color number
+func validColor(value string) bool {
+	if hexColorPattern.MatchString(value) {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// expandSides expands 1 to 4 values given in CSS order to top, right,
+// bottom and left
+func expandSides[T any](values []T) (top, right, bottom, left T) {
+	switch len(values) {
+	case 1:
+		return values[0], values[0], values[0], values[0]
+	case 2:
+		return values[0], values[1], values[0], values[1]
+	case 3:
+		return values[0], values[1], values[2], values[1]
+	default:
+		return values[0], values[1], values[2], values[3]
+	}
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadThemes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "10-ocean.toml"), `
+name = "ocean"
+extends = "catppuccin-mocha"
+variant = "ocean-light" # Defined in a later file
+`)
+	writeFile(t, filepath.Join(dir, "20-ocean-light.yaml"), `
+dark: false
+variant: ocean
+name: ocean-light
+palette:
+  base: "#eeeeff"
+`+fullPalette("  ", "#123456"))
+	writeFile(t, filepath.Join(dir, "notes.txt"), "not a theme")
+	writeFile(t, filepath.Join(dir, "nested.json", "theme.json"), "{}")
+
+	themes, err := LoadThemes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(themeNames(themes), ","); got != "ocean,ocean-light" {
+		t.Fatalf("loaded %s", got)
+	}
+	ocean, light := themes[0], themes[1]
+	if ocean.Variant != "ocean-light" || light.Dark || light.Palette.Base != "#eeeeff" || light.Palette.Text != "#123456" {
+		t.Errorf("themes %+v and %+v", ocean, light)
+	}
+	if got := ocean.variantFor(AvailableThemes(themes), false); got != light {
+		t.Errorf("light variant of ocean is %s", got.Name)
+	}
+}
+
+func TestThemeFileMapping(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ocean.toml"), `
+extends = "catppuccin-mocha"
+
+[palette]
+base = "#001122"
+text = "15"
+
+[styles.active_tab]
+background = "yellow"
+foreground = "#000000"
+padding = [0, 3]
+bold = false
+
+[styles.content_box]
+border = "double"
+border_sides = [true, false]
+border_foreground = "peach"
+align = "center"
+`)
+	themes, err := LoadThemes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ocean := themes[0]
+	mocha, _ := findTheme(builtinThemes, "catppuccin-mocha")
+
+	// Palette roles are the lowercase field names; the rest comes from extends
+	if ocean.Name != "ocean" || ocean.Title != "ocean" || !ocean.Dark {
+		t.Errorf("theme %+v", ocean)
+	}
+	if ocean.Palette.Base != "#001122" || ocean.Palette.Text != "15" || ocean.Palette.Mauve != mocha.Palette.Mauve {
+		t.Errorf("palette %+v", ocean.Palette)
+	}
+
+	styles := NewPortfolioStyles(ocean)
+	tab := styles.ActiveTab
+	if tab.GetBackground() != ocean.Palette.Yellow || tab.GetForeground() != lipgloss.Color("#000000") {
+		t.Errorf("active tab colors %v on %v", tab.GetForeground(), tab.GetBackground())
+	}
+	if top, right, bottom, left := tab.GetPadding(); top != 0 || right != 3 || bottom != 0 || left != 3 {
+		t.Errorf("active tab padding %d %d %d %d", top, right, bottom, left)
+	}
+	if tab.GetBold() {
+		t.Error("active tab is still bold")
+	}
+
+	box := styles.ContentBox
+	if box.GetBorderStyle() != lipgloss.DoubleBorder() {
+		t.Error("content box border isn't double")
+	}
+	if !box.GetBorderTop() || box.GetBorderRight() || !box.GetBorderBottom() || box.GetBorderLeft() {
+		t.Error("content box border sides weren't expanded as in CSS")
+	}
+	if box.GetBorderTopForeground() != ocean.Palette.Peach {
+		t.Errorf("content box border color %v", box.GetBorderTopForeground())
+	}
+	if box.GetAlignHorizontal() != lipgloss.Center {
+		t.Error("content box isn't centered")
+	}
+
+	// Styles the file leaves alone match the extended theme
+	if got, want := styles.SectionTitle.GetForeground(), NewPortfolioStyles(mocha).SectionTitle.GetForeground(); got != want {
+		t.Errorf("title color %v, want %v", got, want)
+	}
+}
+
+func TestLoadThemesReportsEveryProblem(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a-good.json"), `{"extends": "nord", "variant": "missing"}`)
+	writeFile(t, filepath.Join(dir, "b-bad.toml"), `
+name = "bad theme"
+extends = "nope"
+
+[palette]
+base = "blue"
+shiny = "#fff"
+
+[styles.active_tab]
+border = "wavy"
+padding = [1, 2, 3, 4, 5]
+foreground = "nothing"
+
+[styles.sidebar]
+bold = true
+`)
+	writeFile(t, filepath.Join(dir, "c-partial.yaml"), "palette:\n  base: \"#000000\"\n")
+	writeFile(t, filepath.Join(dir, "d-typo.json"), `{"extends": "nord", "pallete": {}}`)
+	writeFile(t, filepath.Join(dir, "e-dup.json"), `{"name": "Nord", "extends": "nord"}`)
+
+	themes, err := LoadThemes(dir)
+	if err == nil {
+		t.Fatal("no error")
+	}
+
+	// Good themes are still loaded, without the unknown variant
+	if len(themes) != 1 || themes[0].Name != "a-good" || themes[0].Variant != "" {
+		t.Errorf("loaded %v", themeNames(themes))
+	}
+
+	problems := strings.Split(err.Error(), "\n")
+	for _, want := range []string{
+		"a-good.json: variant: unknown theme \"missing\"",
+		"b-bad.toml: name \"bad theme\" can't contain spaces",
+		"b-bad.toml: extends: unknown theme \"nope\"",
+		"b-bad.toml: palette.base: \"blue\" is not a hex color",
+		"b-bad.toml: palette.shiny: unknown color",
+		"b-bad.toml: styles.active_tab.border: unknown border \"wavy\"",
+		"b-bad.toml: styles.active_tab.padding: expected 1 to 4 values",
+		"b-bad.toml: styles.active_tab.foreground: \"nothing\" is not",
+		"b-bad.toml: styles.sidebar: unknown style",
+		"c-partial.yaml: palette: missing mantle",
+		"d-typo.json: json: unknown field \"pallete\"",
+		"e-dup.json: theme \"nord\" is already defined",
+	} {
+		found := false
+		for _, problem := range problems {
+			if strings.HasPrefix(problem, filepath.Join(dir, "")) && strings.Contains(problem, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing problem %q in:\n%v", want, err)
+		}
+	}
+	if len(problems) != 12 {
+		t.Errorf("%d problems, want 12:\n%v", len(problems), err)
+	}
+}
+
+func TestLoadThemesExamples(t *testing.T) {
+	themes, err := LoadThemes(filepath.Join("..", "..", "themes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(themes) == 0 {
+		t.Error("no example themes loaded")
+	}
+}
+
+// fullPalette returns YAML setting every palette color to color
+func fullPalette(indent, color string) string {
+	var b strings.Builder
+	for _, role := range paletteRoles()[1:] {
+		b.WriteString(indent + role + ": \"" + color + "\"\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultThemeName is used when neither the server config nor the portfolio
+// data picks a theme
+const DefaultThemeName = "catppuccin-mocha"
+
+// HighContrastThemeName is the theme accessible mode switches to, or its
+// light variant on light backgrounds
+const HighContrastThemeName = "high-contrast"
+
+// Palette holds the colors a theme draws with. The roles are Catppuccin's:
+// Base and Mantle are backgrounds, Surface colors fill tabs and boxes, Text
+// down to Overlay1 go from the brightest to the most muted text, and the
+// rest are accents.
+type Palette struct {
+	Base     lipgloss.Color
+	Mantle   lipgloss.Color
+	Surface0 lipgloss.Color
+	Surface1 lipgloss.Color
+
+	Text     lipgloss.Color
+	Subtext1 lipgloss.Color
+	Subtext0 lipgloss.Color
+	Overlay2 lipgloss.Color
+	Overlay1 lipgloss.Color
+
+	Lavender  lipgloss.Color
+	Blue      lipgloss.Color
+	Sapphire  lipgloss.Color
+	Sky       lipgloss.Color
+	Teal      lipgloss.Color
+	Green     lipgloss.Color
+	Yellow    lipgloss.Color
+	Peach     lipgloss.Color
+	Mauve     lipgloss.Color
+	Pink      lipgloss.Color
+	Flamingo  lipgloss.Color
+	Maroon    lipgloss.Color
+	Red       lipgloss.Color
+	Rosewater lipgloss.Color
+}
+
+// Theme is a named palette the portfolio can be drawn in
+type Theme struct {
+	Name    string // Used in config and data files, e.g. catppuccin-mocha
+	Title   string // Shown to visitors, e.g. Catppuccin Mocha
+	Dark    bool   // Whether the theme is meant for dark backgrounds
+	Variant string // Theme to use on the opposite background, if any
+	Palette Palette
+
+	overrides map[string]StyleOverride // Style changes from a theme file
+}
+
+// explosionColors returns the accents particles are drawn in
+func (t *Theme) explosionColors() []lipgloss.Color {
+	p := t.Palette
+	return []lipgloss.Color{
+		p.Pink, p.Mauve, p.Lavender, p.Blue, p.Sapphire, p.Sky, p.Teal,
+		p.Green, p.Yellow, p.Peach, p.Maroon, p.Red, p.Flamingo, p.Rosewater,
+	}
+}
+
+// builtinThemes are the themes every server has, in the order the theme key
+// cycles through them
+var builtinThemes = []*Theme{
+	{
+		Name:    "catppuccin-latte",
+		Title:   "Catppuccin Latte",
+		Variant: "catppuccin-mocha",
+		Palette: Palette{
+			Base: "#eff1f5", Mantle: "#e6e9ef", Surface0: "#ccd0da", Surface1: "#bcc0cc",
+			Text: "#4c4f69", Subtext1: "#5c5f77", Subtext0: "#6c6f85", Overlay2: "#7c7f93", Overlay1: "#8c8fa1",
+			Lavender: "#7287fd", Blue: "#1e66f5", Sapphire: "#209fb5", Sky: "#04a5e5", Teal: "#179299",
+			Green: "#40a02b", Yellow: "#df8e1d", Peach: "#fe640b", Mauve: "#8839ef", Pink: "#ea76cb",
+			Flamingo: "#dd7878", Maroon: "#e64553", Red: "#d20f39", Rosewater: "#dc8a78",
+		},
+	},
+	{
+		Name:    "catppuccin-frappe",
+		Title:   "Catppuccin Frappé",
+		Dark:    true,
+		Variant: "catppuccin-latte",
+		Palette: Palette{
+			Base: "#303446", Mantle: "#292c3c", Surface0: "#414559", Surface1: "#51576d",
+			Text: "#c6d0f5", Subtext1: "#b5bfe2", Subtext0: "#a5adce", Overlay2: "#949cbb", Overlay1: "#838ba7",
+			Lavender: "#babbf1", Blue: "#8caaee", Sapphire: "#85c1dc", Sky: "#99d1db", Teal: "#81c8be",
+			Green: "#a6d189", Yellow: "#e5c890", Peach: "#ef9f76", Mauve: "#ca9ee6", Pink: "#f4b8e4",
+			Flamingo: "#eebebe", Maroon: "#ea999c", Red: "#e78284", Rosewater: "#f2d5cf",
+		},
+	},
+	{
+		Name:    "catppuccin-macchiato",
+		Title:   "Catppuccin Macchiato",
+		Dark:    true,
+		Variant: "catppuccin-latte",
+		Palette: Palette{
+			Base: "#24273a", Mantle: "#1e2030", Surface0: "#363a4f", Surface1: "#494d64",
+			Text: "#cad3f5", Subtext1: "#b8c0e0", Subtext0: "#a5adcb", Overlay2: "#939ab7", Overlay1: "#8087a2",
+			Lavender: "#b7bdf8", Blue: "#8aadf4", Sapphire: "#7dc4e4", Sky: "#91d7e3", Teal: "#8bd5ca",
+			Green: "#a6da95", Yellow: "#eed49f", Peach: "#f5a97f", Mauve: "#c6a0f6", Pink: "#f5bde6",
+			Flamingo: "#f0c6c6", Maroon: "#ee99a0", Red: "#ed8796", Rosewater: "#f4dbd6",
+		},
+	},
+	{
+		Name:    "catppuccin-mocha",
+		Title:   "Catppuccin Mocha",
+		Dark:    true,
+		Variant: "catppuccin-latte",
+		Palette: Palette{
+			Base: "#1e1e2e", Mantle: "#181825", Surface0: "#313244", Surface1: "#45475a",
+			Text: "#cdd6f4", Subtext1: "#bac2de", Subtext0: "#a6adc8", Overlay2: "#9399b2", Overlay1: "#7f849c",
+			Lavender: "#b4befe", Blue: "#89b4fa", Sapphire: "#74c7ec", Sky: "#89dceb", Teal: "#94e2d5",
+			Green: "#a6e3a1", Yellow: "#f9e2af", Peach: "#fab387", Mauve: "#cba6f7", Pink: "#f5c2e7",
+			Flamingo: "#f2cdcd", Maroon: "#eba0ac", Red: "#f38ba8", Rosewater: "#f5e0dc",
+		},
+	},
+	{
+		Name:    "gruvbox",
+		Title:   "Gruvbox",
+		Dark:    true,
+		Variant: "gruvbox-light",
+		Palette: Palette{
+			Base: "#282828", Mantle: "#1d2021", Surface0: "#3c3836", Surface1: "#504945",
+			Text: "#ebdbb2", Subtext1: "#d5c4a1", Subtext0: "#bdae93", Overlay2: "#a89984", Overlay1: "#928374",
+			Lavender: "#83a598", Blue: "#83a598", Sapphire: "#458588", Sky: "#8ec07c", Teal: "#689d6a",
+			Green: "#b8bb26", Yellow: "#fabd2f", Peach: "#fe8019", Mauve: "#d3869b", Pink: "#d3869b",
+			Flamingo: "#fe8019", Maroon: "#cc241d", Red: "#fb4934", Rosewater: "#fbf1c7",
+		},
+	},
+	{
+		Name:    "gruvbox-light",
+		Title:   "Gruvbox Light",
+		Variant: "gruvbox",
+		Palette: Palette{
+			Base: "#fbf1c7", Mantle: "#f2e5bc", Surface0: "#ebdbb2", Surface1: "#d5c4a1",
+			Text: "#3c3836", Subtext1: "#504945", Subtext0: "#665c54", Overlay2: "#7c6f64", Overlay1: "#928374",
+			Lavender: "#076678", Blue: "#076678", Sapphire: "#458588", Sky: "#427b58", Teal: "#427b58",
+			Green: "#79740e", Yellow: "#b57614", Peach: "#af3a03", Mauve: "#8f3f71", Pink: "#b16286",
+			Flamingo: "#d65d0e", Maroon: "#cc241d", Red: "#9d0006", Rosewater: "#7c6f64",
+		},
+	},
+	{
+		Name:    "nord",
+		Title:   "Nord",
+		Dark:    true,
+		Variant: "nord-light",
+		Palette: Palette{
+			Base: "#2e3440", Mantle: "#272c36", Surface0: "#3b4252", Surface1: "#434c5e",
+			Text: "#eceff4", Subtext1: "#e5e9f0", Subtext0: "#d8dee9", Overlay2: "#a5afc2", Overlay1: "#7b88a1",
+			Lavender: "#88c0d0", Blue: "#81a1c1", Sapphire: "#5e81ac", Sky: "#88c0d0", Teal: "#8fbcbb",
+			Green: "#a3be8c", Yellow: "#ebcb8b", Peach: "#d08770", Mauve: "#b48ead", Pink: "#b48ead",
+			Flamingo: "#d08770", Maroon: "#bf616a", Red: "#bf616a", Rosewater: "#e5e9f0",
+		},
+	},
+	{
+		Name:    "nord-light",
+		Title:   "Nord Light",
+		Variant: "nord",
+		Palette: Palette{
+			Base: "#eceff4", Mantle: "#e5e9f0", Surface0: "#d8dee9", Surface1: "#c2cad8",
+			Text: "#2e3440", Subtext1: "#3b4252", Subtext0: "#434c5e", Overlay2: "#4c566a", Overlay1: "#616e88",
+			Lavender: "#5e81ac", Blue: "#5e81ac", Sapphire: "#4c6f9a", Sky: "#3e7d8c", Teal: "#4f8a87",
+			Green: "#5f7f45", Yellow: "#9a7b2c", Peach: "#b0614a", Mauve: "#8c6a8a", Pink: "#9c5a8c",
+			Flamingo: "#b0614a", Maroon: "#a5454f", Red: "#bf616a", Rosewater: "#4c566a",
+		},
+	},
+	{
+		Name:    "dracula",
+		Title:   "Dracula",
+		Dark:    true,
+		Variant: "dracula-light",
+		Palette: Palette{
+			Base: "#282a36", Mantle: "#21222c", Surface0: "#44475a", Surface1: "#565a70",
+			Text: "#f8f8f2", Subtext1: "#e6e6e0", Subtext0: "#c8c8d0", Overlay2: "#9aa3cc", Overlay1: "#7b86b8",
+			Lavender: "#bd93f9", Blue: "#8be9fd", Sapphire: "#62d6e8", Sky: "#8be9fd", Teal: "#69ff94",
+			Green: "#50fa7b", Yellow: "#f1fa8c", Peach: "#ffb86c", Mauve: "#bd93f9", Pink: "#ff79c6",
+			Flamingo: "#ff92df", Maroon: "#ff6e6e", Red: "#ff5555", Rosewater: "#f8f8f2",
+		},
+	},
+	{
+		Name:    "dracula-light",
+		Title:   "Dracula Light (Alucard)",
+		Variant: "dracula",
+		Palette: Palette{
+			Base: "#fffbeb", Mantle: "#f5f0dc", Surface0: "#efe9d3", Surface1: "#cfcfde",
+			Text: "#1f1f1f", Subtext1: "#2f2f2f", Subtext0: "#4a4733", Overlay2: "#6c664b", Overlay1: "#8a8468",
+			Lavender: "#644ac9", Blue: "#036a96", Sapphire: "#036a96", Sky: "#036a96", Teal: "#14710a",
+			Green: "#14710a", Yellow: "#846e15", Peach: "#a34d14", Mauve: "#644ac9", Pink: "#a3144d",
+			Flamingo: "#a3144d", Maroon: "#cb3a2a", Red: "#cb3a2a", Rosewater: "#1f1f1f",
+		},
+	},
+	{
+		// Every color is at least 7:1 against the background (WCAG AAA)
+		Name:    "high-contrast",
+		Title:   "High Contrast",
+		Dark:    true,
+		Variant: "high-contrast-light",
+		Palette: Palette{
+			Base: "#000000", Mantle: "#000000", Surface0: "#000000", Surface1: "#ffffff",
+			Text: "#ffffff", Subtext1: "#ffffff", Subtext0: "#ffffff", Overlay2: "#ffffff", Overlay1: "#ffffff",
+			Lavender: "#ffffff", Blue: "#00ffff", Sapphire: "#00ffff", Sky: "#00ffff", Teal: "#00ffff",
+			Green: "#00ff00", Yellow: "#ffff00", Peach: "#ffff00", Mauve: "#ffff00", Pink: "#ff80ff",
+			Flamingo: "#ffff00", Maroon: "#ff8080", Red: "#ff8080", Rosewater: "#ffffff",
+		},
+	},
+	{
+		Name:    "high-contrast-light",
+		Title:   "High Contrast Light",
+		Variant: "high-contrast",
+		Palette: Palette{
+			Base: "#ffffff", Mantle: "#ffffff", Surface0: "#ffffff", Surface1: "#000000",
+			Text: "#000000", Subtext1: "#000000", Subtext0: "#000000", Overlay2: "#000000", Overlay1: "#000000",
+			Lavender: "#000000", Blue: "#0000a0", Sapphire: "#0000a0", Sky: "#0000a0", Teal: "#0000a0",
+			Green: "#005a00", Yellow: "#5a3c00", Peach: "#7a2e00", Mauve: "#4b0082", Pink: "#800040",
+			Flamingo: "#7a2e00", Maroon: "#a00000", Red: "#a00000", Rosewater: "#000000",
+		},
+	},
+}
+
+// AvailableThemes returns the themes sessions can use, in cycling order: the
+// built-in ones, then user themes (see LoadThemes)
+func AvailableThemes(user []*Theme) []*Theme {
+	return slices.Concat(builtinThemes, user)
+}
+
+// ThemeNames returns the names of the built-in themes, in cycling order
+func ThemeNames() []string {
+	return themeNames(builtinThemes)
+}
+
+func themeNames(list []*Theme) []string {
+	names := make([]string, len(list))
+	for i, theme := range list {
+		names[i] = theme.Name
+	}
+	return names
+}
+
+// findTheme finds a theme in list by name, ignoring case
+func findTheme(list []*Theme, name string) (*Theme, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, theme := range list {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+// variantFor returns the theme to use on a dark or light background: the
+// theme itself if it suits, else its variant among themes if it has one that
+// does
+func (t *Theme) variantFor(themes []*Theme, dark bool) *Theme {
+	if t.Dark == dark {
+		return t
+	}
+	if variant, ok := findTheme(themes, t.Variant); ok && variant.Dark == dark {
+		return variant
+	}
+	return t
+}
+
+// nextTheme returns the theme after the given one in themes
+func nextTheme(themes []*Theme, current *Theme) *Theme {
+	for i, theme := range themes {
+		if theme == current {
+			return themes[(i+1)%len(themes)]
+		}
+	}
+	return themes[0]
+}
+
+// defaultTheme returns the theme new sessions start with: the one set in the
+// server config, else the one set in the portfolio data, else Catppuccin
+// Mocha. Unknown names fall through to the next choice.
+func (c *ServerConfig) defaultTheme(data *PortfolioData) *Theme {
+	themes := c.availableThemes()
+	if theme, ok := findTheme(themes, c.Theme); ok {
+		return theme
+	}
+	if data != nil {
+		if theme, ok := findTheme(themes, data.Theme); ok {
+			return theme
+		}
+	}
+	theme, _ := findTheme(builtinThemes, DefaultThemeName)
+	return theme
+}
+
+// availableThemes returns the themes sessions can use, the built-in ones
+// when none are configured
+func (c *ServerConfig) availableThemes() []*Theme {
+	if len(c.Themes) == 0 {
+		return builtinThemes
+	}
+	return c.Themes
+}
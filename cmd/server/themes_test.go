@@ -0,0 +1,46 @@
+package server
+
+import "testing"
+
+func TestSessionThemes(t *testing.T) {
+	light := &Theme{Name: "mine-light", Variant: "mine"}
+	dark := &Theme{Name: "mine", Dark: true, Variant: "mine-light"}
+	themes := AvailableThemes([]*Theme{dark, light})
+
+	config := &ServerConfig{
+		DataLoader: newTestLoader(t),
+		Programs:   NewProgramRegistry(),
+		Theme:      "MINE",
+		Themes:     themes,
+	}
+	m := NewPortfolioModel(80, 24, config)
+	if m.theme != dark {
+		t.Fatalf("started with %s, want mine", m.theme.Name)
+	}
+
+	// The variant is found among the session's themes
+	if got := dark.variantFor(m.themes, false); got != light {
+		t.Errorf("light variant %s, want mine-light", got.Name)
+	}
+	if got := dark.variantFor(builtinThemes, false); got != dark {
+		t.Errorf("variant %s found outside the given themes", got.Name)
+	}
+
+	// Cycling goes through every theme and wraps around
+	if got := nextTheme(m.themes, light); got != themes[0] {
+		t.Errorf("after the last theme came %s, want %s", got.Name, themes[0].Name)
+	}
+	seen := map[*Theme]bool{}
+	for theme := m.theme; !seen[theme]; theme = nextTheme(m.themes, theme) {
+		seen[theme] = true
+	}
+	if len(seen) != len(themes) {
+		t.Errorf("cycled through %d themes, want %d", len(seen), len(themes))
+	}
+
+	// Without configured themes, sessions use the built-in ones
+	config.Themes = nil
+	if got := config.defaultTheme(nil); got.Name != DefaultThemeName {
+		t.Errorf("default theme %s, want %s", got.Name, DefaultThemeName)
+	}
+}
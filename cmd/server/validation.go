@@ -0,0 +1,293 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Severity tells whether a validation issue prevents the data from being used
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue is a single problem found in portfolio data. Path uses the
+// JSON field names, e.g. skills.Backend[2].percentage.
+type ValidationIssue struct {
+	Path     string
+	Message  string
+	Severity Severity
+}
+
+func (i ValidationIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// ValidationError reports every error level issue found in portfolio data
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Issues) == 1 {
+		return e.Issues[0].String()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d validation errors:", len(e.Issues))
+	for _, issue := range e.Issues {
+		b.WriteString("\n  " + issue.String())
+	}
+	return b.String()
+}
+
+// validationError returns a *ValidationError for the error level issues, or
+// nil if there are only warnings
+func validationError(issues []ValidationIssue) error {
+	var errs []ValidationIssue
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: errs}
+}
+
+// ValidationWarnings returns only the warning level issues
+func ValidationWarnings(issues []ValidationIssue) []ValidationIssue {
+	var warnings []ValidationIssue
+	for _, issue := range issues {
+		if issue.Severity == SeverityWarning {
+			warnings = append(warnings, issue)
+		}
+	}
+	return warnings
+}
+
+// Keep in sync with data/portfolio.schema.json
+const (
+	maxSkillPercentage = 100
+	skillNameWidth     = 15 // Column width used by renderSkillBar
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type validator struct {
+	issues []ValidationIssue
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+func (v *validator) warnf(path, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityWarning})
+}
+
+func (v *validator) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.errorf(path, "is required")
+	}
+}
+
+// ValidatePortfolioData checks portfolio data and returns every problem found,
+// errors and warnings alike. The data may pick any of themes, or any
+// built-in theme when themes is nil.
+func ValidatePortfolioData(data *PortfolioData, themes []*Theme) []ValidationIssue {
+	if data == nil {
+		return []ValidationIssue{{Message: "no data loaded", Severity: SeverityError}}
+	}
+
+	v := &validator{}
+	v.validatePersonal("personal", &data.Personal)
+	v.validateExperiences("experiences", data.Experiences)
+	v.validateSkills("skills", data.Skills)
+	v.validateProjects("projects", data.Projects)
+
+	if len(data.TechFacts) == 0 {
+		v.warnf("techFacts", "no tech facts, a placeholder will be shown")
+	}
+	for i, fact := range data.TechFacts {
+		if strings.TrimSpace(fact) == "" {
+			v.errorf(fmt.Sprintf("techFacts[%d]", i), "must not be empty")
+		}
+	}
+
+	if themes == nil {
+		themes = builtinThemes
+	}
+	if _, ok := findTheme(themes, data.Theme); data.Theme != "" && !ok {
+		v.warnf("theme", "unknown theme %q, %s will be used (expected %s)", data.Theme, DefaultThemeName, strings.Join(themeNames(themes), ", "))
+	}
+
+	return v.issues
+}
+
+func (v *validator) validatePersonal(path string, personal *PersonalInfo) {
+	v.required(path+".name", personal.Name)
+
+	contactPath := path + ".contact"
+	contact := personal.Contact
+	v.required(contactPath+".email", contact.Email)
+	if contact.Email != "" {
+		if addr, err := mail.ParseAddress(contact.Email); err != nil || addr.Address != contact.Email {
+			v.errorf(contactPath+".email", "%q is not a valid email address", contact.Email)
+		}
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"github", contact.GitHub},
+		{"linkedin", contact.LinkedIn},
+		{"portfolio", contact.Portfolio},
+	} {
+		if field.value != "" && !isLinkLike(field.value) {
+			v.errorf(contactPath+"."+field.name, "%q is not a valid URL", field.value)
+		}
+	}
+
+	v.validateTextList(contactPath+".availableFor", contact.AvailableFor)
+	v.validateTextList(contactPath+".specializations", contact.Specializations)
+	v.validateTextList(path+".about.background", personal.About.Background)
+}
+
+func (v *validator) validateExperiences(path string, experiences []Experience) {
+	if len(experiences) == 0 {
+		v.errorf(path, "at least one experience is required")
+		return
+	}
+
+	current := 0
+	for i, exp := range experiences {
+		expPath := fmt.Sprintf("%s[%d]", path, i)
+		v.required(expPath+".title", exp.Title)
+		v.required(expPath+".company", exp.Company)
+		if strings.TrimSpace(exp.Period) == "" {
+			v.warnf(expPath+".period", "is empty")
+		}
+		if len(exp.Details) == 0 {
+			v.warnf(expPath+".details", "no details listed")
+		}
+		v.validateTextList(expPath+".details", exp.Details)
+		v.validateTextList(expPath+".technologies", exp.Technologies)
+
+		if exp.Current {
+			current++
+		}
+	}
+
+	if current > 1 {
+		v.warnf(path, "%d experiences are marked as current", current)
+	}
+}
+
+func (v *validator) validateSkills(path string, skills map[string][]Skill) {
+	if len(skills) == 0 {
+		v.errorf(path, "at least one skill category is required")
+		return
+	}
+
+	// Sort categories so issues are reported in a stable order
+	categories := make([]string, 0, len(skills))
+	for category := range skills {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		categoryPath := path + jsonPathKey(category)
+		if strings.TrimSpace(category) == "" {
+			v.errorf(categoryPath, "category name must not be empty")
+		}
+		if len(skills[category]) == 0 {
+			v.warnf(categoryPath, "category has no skills")
+		}
+
+		seen := make(map[string]bool)
+		for i, skill := range skills[category] {
+			skillPath := fmt.Sprintf("%s[%d]", categoryPath, i)
+			v.required(skillPath+".name", skill.Name)
+			if skill.Percentage < 0 || skill.Percentage > maxSkillPercentage {
+				v.errorf(skillPath+".percentage", "must be 0-%d, got %d", maxSkillPercentage, skill.Percentage)
+			}
+			if strings.TrimSpace(skill.Experience) == "" {
+				v.warnf(skillPath+".experience", "is empty")
+			}
+			if utf8.RuneCountInString(skill.Name) > skillNameWidth {
+				v.warnf(skillPath+".name", "longer than %d characters, skill bars will not line up", skillNameWidth)
+			}
+			if seen[skill.Name] {
+				v.warnf(skillPath+".name", "duplicate skill %q", skill.Name)
+			}
+			seen[skill.Name] = true
+		}
+	}
+}
+
+func (v *validator) validateProjects(path string, projects []Project) {
+	for i, project := range projects {
+		projectPath := fmt.Sprintf("%s[%d]", path, i)
+		v.required(projectPath+".name", project.Name)
+		v.required(projectPath+".description", project.Description)
+		if project.RepoURL != "" && !isLinkLike(project.RepoURL) {
+			v.errorf(projectPath+".repoUrl", "%q is not a valid URL", project.RepoURL)
+		}
+		if project.EndDate != "" && project.StartDate == "" {
+			v.warnf(projectPath+".startDate", "endDate is set without a startDate")
+		}
+		v.validateTextList(projectPath+".stack", project.Stack)
+		v.validateTextList(projectPath+".highlights", project.Highlights)
+	}
+}
+
+// validateTextList reports empty entries in a list of strings
+func (v *validator) validateTextList(path string, items []string) {
+	for i, item := range items {
+		if strings.TrimSpace(item) == "" {
+			v.errorf(fmt.Sprintf("%s[%d]", path, i), "must not be empty")
+		}
+	}
+}
+
+// isLinkLike accepts full URLs, including ones to local hosts like
+// http://localhost:8080, as well as scheme-less ones like github.com/user
+func isLinkLike(value string) bool {
+	if strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+	hasScheme := strings.Contains(value, "://")
+	if !hasScheme {
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+	// Without a scheme, a single word is more likely a mistake than a host
+	host := u.Hostname()
+	return hasScheme || strings.Contains(host, ".") || host == "localhost" || net.ParseIP(host) != nil
+}
+
+// jsonPathKey formats a map key as a path segment, quoting keys that aren't
+// plain identifiers
+func jsonPathKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return "." + key
+	}
+	return fmt.Sprintf("[%q]", key)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testPortfolioData returns a fresh copy of testPortfolioJSON
+func testPortfolioData(t *testing.T) *PortfolioData {
+	t.Helper()
+	var data PortfolioData
+	if err := json.Unmarshal([]byte(fmt.Sprintf(testPortfolioJSON, "Ada")), &data); err != nil {
+		t.Fatal(err)
+	}
+	return &data
+}
+
+func TestValidatePortfolioData(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(*PortfolioData)
+		severity Severity // Of the issue expected at path, "" for none at all
+		path     string
+	}{
+		{"valid", func(*PortfolioData) {}, "", ""},
+
+		{"missing name", func(d *PortfolioData) { d.Personal.Name = " " }, SeverityError, "personal.name"},
+		{"missing email", func(d *PortfolioData) { d.Personal.Contact.Email = "" }, SeverityError, "personal.contact.email"},
+		{"bad email", func(d *PortfolioData) { d.Personal.Contact.Email = "me at example.com" }, SeverityError, "personal.contact.email"},
+		{"email with name", func(d *PortfolioData) { d.Personal.Contact.Email = "Me <me@example.com>" }, SeverityError, "personal.contact.email"},
+		{"email without domain dot", func(d *PortfolioData) { d.Personal.Contact.Email = "me@localhost" }, "", ""},
+
+		{"link with spaces", func(d *PortfolioData) { d.Personal.Contact.GitHub = "github.com/my name" }, SeverityError, "personal.contact.github"},
+		{"link without host", func(d *PortfolioData) { d.Personal.Contact.LinkedIn = "https://" }, SeverityError, "personal.contact.linkedin"},
+		{"single word link", func(d *PortfolioData) { d.Personal.Contact.Portfolio = "portfolio" }, SeverityError, "personal.contact.portfolio"},
+		{"link with scheme", func(d *PortfolioData) { d.Personal.Contact.Portfolio = "https://example.com/me" }, "", ""},
+		{"localhost link", func(d *PortfolioData) { d.Personal.Contact.Portfolio = "http://localhost:8080" }, "", ""},
+		{"localhost without scheme", func(d *PortfolioData) { d.Personal.Contact.Portfolio = "localhost:8080/me" }, "", ""},
+		{"IP link", func(d *PortfolioData) { d.Projects[0].RepoURL = "http://192.168.1.10:3000/repo" }, "", ""},
+		{"single word host with scheme", func(d *PortfolioData) { d.Projects[0].RepoURL = "http://gitea/me/repo" }, "", ""},
+		{"bad repo link", func(d *PortfolioData) { d.Projects[0].RepoURL = "not a url" }, SeverityError, "projects[0].repoUrl"},
+
+		{"empty list entry", func(d *PortfolioData) { d.Personal.About.Background[1] = "" }, SeverityError, "personal.about.background[1]"},
+		{"no experiences", func(d *PortfolioData) { d.Experiences = nil }, SeverityError, "experiences"},
+		{"experience without company", func(d *PortfolioData) { d.Experiences[0].Company = "" }, SeverityError, "experiences[0].company"},
+		{"experience without period", func(d *PortfolioData) { d.Experiences[0].Period = "" }, SeverityWarning, "experiences[0].period"},
+		{"two current jobs", func(d *PortfolioData) { d.Experiences = append(d.Experiences, d.Experiences[0]) }, SeverityWarning, "experiences"},
+
+		{"no skills", func(d *PortfolioData) { d.Skills = nil }, SeverityError, "skills"},
+		{"percentage too high", func(d *PortfolioData) { d.Skills["Languages"][0].Percentage = 101 }, SeverityError, "skills.Languages[0].percentage"},
+		{"long skill name", func(d *PortfolioData) { d.Skills["Languages"][0].Name = "A very long skill name" }, SeverityWarning, "skills.Languages[0].name"},
+		{"quoted category", func(d *PortfolioData) { d.Skills["Dev Ops"] = nil }, SeverityWarning, `skills["Dev Ops"]`},
+		{"duplicate skill", func(d *PortfolioData) {
+			d.Skills["Languages"] = append(d.Skills["Languages"], d.Skills["Languages"][0])
+		}, SeverityWarning, "skills.Languages[1].name"},
+
+		{"project without description", func(d *PortfolioData) { d.Projects[0].Description = "" }, SeverityError, "projects[0].description"},
+		{"end without start", func(d *PortfolioData) { d.Projects[0].EndDate = "2024" }, SeverityWarning, "projects[0].startDate"},
+		{"no tech facts", func(d *PortfolioData) { d.TechFacts = nil }, SeverityWarning, "techFacts"},
+		{"unknown theme", func(d *PortfolioData) { d.Theme = "neon" }, SeverityWarning, "theme"},
+		{"known theme", func(d *PortfolioData) { d.Theme = "Nord" }, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := testPortfolioData(t)
+			tt.change(data)
+			issues := ValidatePortfolioData(data, nil)
+
+			if tt.severity == "" {
+				if len(issues) > 0 {
+					t.Errorf("unexpected issues: %v", issues)
+				}
+				return
+			}
+			for _, issue := range issues {
+				if issue.Path == tt.path && issue.Severity == tt.severity {
+					return
+				}
+			}
+			t.Errorf("no %s at %s in %v", tt.severity, tt.path, issues)
+		})
+	}
+}
+
+func TestValidatePortfolioDataUserThemes(t *testing.T) {
+	data := testPortfolioData(t)
+	data.Theme = "mine"
+	if issues := ValidatePortfolioData(data, AvailableThemes([]*Theme{{Name: "mine"}})); len(issues) > 0 {
+		t.Errorf("a user theme was reported: %v", issues)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	data := testPortfolioData(t)
+	data.Personal.Name = ""
+	data.Skills["Languages"][0].Percentage = -1
+	data.TechFacts = nil
+
+	err := validationError(ValidatePortfolioData(data, nil))
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("got %T, want *ValidationError", err)
+	}
+	// Warnings aren't errors
+	if len(verr.Issues) != 2 {
+		t.Errorf("%d issues, want 2: %v", len(verr.Issues), verr.Issues)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "2 validation errors:") || !strings.Contains(msg, "personal.name: is required") {
+		t.Errorf("message %q", msg)
+	}
+
+	if err := validationError(ValidatePortfolioData(testPortfolioData(t), nil)); err != nil {
+		t.Errorf("valid data: %v", err)
+	}
+	if issues := ValidatePortfolioData(nil, nil); validationError(issues) == nil {
+		t.Error("no data wasn't an error")
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// dataReloadedMsg tells a model that the portfolio data has changed
+type dataReloadedMsg struct{}
+
+// DataWatcher polls the portfolio data file (or Markdown directory) and
+// reloads it when it changes
+type DataWatcher struct {
+	dataLoader *DataLoader
+	programs   *ProgramRegistry
+	metrics    *Metrics
+	interval   time.Duration
+
+	stat [sha256.Size]byte // Paths, sizes and modification times
+	hash [sha256.Size]byte // Contents
+
+	done chan struct{}
+}
+
+// NewDataWatcher creates a watcher for the data loader's file
+func NewDataWatcher(dataLoader *DataLoader, programs *ProgramRegistry, metrics *Metrics, interval time.Duration) *DataWatcher {
+	return &DataWatcher{
+		dataLoader: dataLoader,
+		programs:   programs,
+		metrics:    metrics,
+		interval:   interval,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start begins polling the data file in the background
+func (w *DataWatcher) Start() {
+	// Record the current state so the initial load doesn't trigger a reload
+	if _, err := w.changed(); err != nil {
+		log.Error("Data watcher failed", "err", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+				w.poll()
+			}
+		}
+	}()
+}
+
+// Stop ends polling. A nil watcher is a no-op.
+func (w *DataWatcher) Stop() {
+	if w == nil {
+		return
+	}
+	close(w.done)
+}
+
+func (w *DataWatcher) poll() {
+	changed, err := w.changed()
+	if err != nil {
+		log.Error("Data watcher failed", "err", err)
+		return
+	}
+	if !changed {
+		return
+	}
+
+	err = w.dataLoader.ReloadData()
+	w.metrics.DataReloaded(err)
+	if err != nil {
+		log.Error("Data file changed but reload failed, keeping previous data", "path", w.dataLoader.dataPath, "err", err)
+		return
+	}
+
+	log.Info("Data file changed, reloaded", "path", w.dataLoader.dataPath)
+	w.programs.Broadcast(dataReloadedMsg{})
+}
+
+// changed reports whether the data differs from the last time it was checked.
+// Content is only hashed when a file was added or removed, or its size or
+// modification time moved. For Markdown directories every file below the
+// directory is taken into account.
+func (w *DataWatcher) changed() (bool, error) {
+	files, err := dataFiles(w.dataLoader.dataPath)
+	if err != nil {
+		return false, err
+	}
+
+	stats := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(stats, "%s\x00%d\x00%d\x00", file.path, file.info.Size(), file.info.ModTime().UnixNano())
+	}
+	var stat [sha256.Size]byte
+	copy(stat[:], stats.Sum(nil))
+	if stat == w.stat {
+		return false, nil
+	}
+
+	hasher := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read data file: %w", err)
+		}
+		hasher.Write([]byte(file.path))
+		hasher.Write(data)
+	}
+
+	w.stat = stat
+
+	var hash [sha256.Size]byte
+	copy(hash[:], hasher.Sum(nil))
+	if hash == w.hash {
+		return false, nil
+	}
+	w.hash = hash
+	return true, nil
+}
+
+type dataFile struct {
+	path string
+	info os.FileInfo
+}
+
+// dataFiles lists the data file, or every regular file below a data directory
+func dataFiles(path string) ([]dataFile, error) {
+	var files []dataFile
+
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, dataFile{path: p, info: info})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat data file: %w", err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// setModTime gives a file a fixed modification time, so tests don't depend
+// on the file system's timestamp resolution
+func setModTime(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDataWatcherChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "portfolio.json")
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	writeFile(t, path, "one")
+	setModTime(t, path, modTime)
+
+	w := NewDataWatcher(NewDataLoader(path, FormatAuto), nil, nil, time.Second)
+	steps := []struct {
+		name   string
+		change func()
+		want   bool
+	}{
+		{"first check", func() {}, true},
+		{"nothing changed", func() {}, false},
+		{"touched", func() { setModTime(t, path, modTime.Add(time.Minute)) }, false},
+		{"rewritten with the same size and time", func() {
+			writeFile(t, path, "two")
+			setModTime(t, path, modTime.Add(time.Minute))
+		}, false},
+		{"rewritten", func() {
+			writeFile(t, path, "three")
+			setModTime(t, path, modTime.Add(time.Minute))
+		}, true},
+		{"same content, new time", func() { setModTime(t, path, modTime.Add(time.Hour)) }, false},
+	}
+
+	for _, step := range steps {
+		step.change()
+		changed, err := w.changed()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if changed != step.want {
+			t.Errorf("%s: changed = %v, want %v", step.name, changed, step.want)
+		}
+	}
+}
+
+func TestDataWatcherMarkdownDir(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	writeFile(t, filepath.Join(dir, "about.md"), "---\nname: Ada\ntitle: Developer\n---\nHello.\n")
+	writeFile(t, filepath.Join(dir, "contact.md"), "---\nemail: me@example.com\n---\n")
+	writeFile(t, filepath.Join(dir, "skills.md"), "---\nskills:\n  Languages:\n    - name: Go\n      percentage: 90\n---\n")
+	writeFile(t, filepath.Join(dir, "experience", "01-acme.md"), "---\ntitle: Dev\ncompany: Acme\nperiod: 2020 - Present\n---\n- Shipped it\n")
+	project := filepath.Join(dir, "projects", "01-tool.md")
+	writeFile(t, project, "---\nname: Tool\nstatus: Active\n---\nA tool.\n")
+	setModTime(t, project, modTime)
+
+	loader := NewDataLoader(dir, FormatAuto)
+	if err := loader.LoadData(); err != nil {
+		t.Fatalf("LoadData: %v", err)
+	}
+	w := NewDataWatcher(loader, nil, nil, time.Second)
+	if _, err := w.changed(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file in a subdirectory changes without the total size moving
+	writeFile(t, project, "---\nname: Tool\nstatus: Active\n---\nA TOOL.\n")
+	setModTime(t, project, modTime.Add(time.Minute))
+	w.poll()
+
+	if got := loader.Snapshot().Projects[0].Description; got != "A TOOL." {
+		t.Errorf("description after a change %q, want %q", got, "A TOOL.")
+	}
+}
+
+// recorder is a model that reports every dataReloadedMsg it receives
+type recorder chan struct{}
+
+func (r recorder) Init() tea.Cmd { return nil }
+
+func (r recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(dataReloadedMsg); ok {
+		r <- struct{}{}
+	}
+	return r, nil
+}
+
+func (r recorder) View() string { return "" }
+
+func TestDataWatcherReload(t *testing.T) {
+	loader := newTestLoader(t)
+	path := loader.dataPath
+
+	reloads := make(recorder, 1)
+	p := tea.NewProgram(reloads, tea.WithInput(nil), tea.WithOutput(io.Discard), tea.WithoutRenderer())
+	programs := NewProgramRegistry()
+	programs.Register(p)
+	go p.Run()
+	defer p.Kill()
+
+	w := NewDataWatcher(loader, programs, nil, time.Second)
+	if _, err := w.changed(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A broken file is reported but the previous data is kept
+	writeFile(t, path, "{")
+	setModTime(t, path, time.Now().Add(time.Minute))
+	w.poll()
+	if got := loader.Snapshot().Personal.Name; got != "Ada" {
+		t.Errorf("name after a failed reload %q, want %q", got, "Ada")
+	}
+	select {
+	case <-reloads:
+		t.Error("failed reload was broadcast")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	writeFile(t, path, fmt.Sprintf(testPortfolioJSON, "Grace"))
+	setModTime(t, path, time.Now().Add(2*time.Minute))
+	w.poll()
+	if got := loader.Snapshot().Personal.Name; got != "Grace" {
+		t.Errorf("name after a reload %q, want %q", got, "Grace")
+	}
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Error("reload wasn't broadcast")
+	}
+}
@@ -0,0 +1,352 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/gorilla/websocket"
+	"github.com/muesli/termenv"
+)
+
+const (
+	webPingInterval = 30 * time.Second
+	webPongTimeout  = 60 * time.Second
+	webReadLimit    = 64 * 1024
+	webMaxSize      = 500 // Largest accepted terminal width or height
+)
+
+// webTerminal serves the portfolio over HTTP: to browsers as a page with an
+// xterm.js terminal and a WebSocket that bridges it to a tea program, and to
+// command line clients as text (see web_text.go)
+type webTerminal struct {
+	config   *ServerConfig
+	ctx      context.Context // Cancelled when the HTTP server shuts down
+	upgrader websocket.Upgrader
+}
+
+// resizeMsg is the control message browsers send when the terminal resizes.
+// Terminal input is sent as binary messages.
+type resizeMsg struct {
+	Type string `json:"type"`
+	Cols int    `json:"cols"`
+	Rows int    `json:"rows"`
+}
+
+// newWebHandler returns the HTTP handler for the browser terminal. Sessions
+// end when ctx is cancelled.
+func newWebHandler(ctx context.Context, config *ServerConfig) http.Handler {
+	t := &webTerminal{
+		config: config,
+		ctx:    ctx,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", t.serveIndex)
+	mux.HandleFunc("GET /ws", t.serveWebSocket)
+	mux.HandleFunc("GET /{section}", t.serveSection)
+	return mux
+}
+
+func (t *webTerminal) servePage(w http.ResponseWriter, r *http.Request) {
+	name := "Portfolio"
+	if personal := t.config.DataLoader.GetPersonalInfo(); personal != nil && personal.Name != "" {
+		name = personal.Name
+	}
+
+	// The page is colored like the theme sessions start in
+	theme := t.startTheme()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := webPageTemplate.Execute(w, struct {
+		Name string
+		Palette
+	}{name, webPalette(theme.Palette)}); err != nil {
+		log.Error("Failed to render web terminal page", "err", err)
+	}
+}
+
+// startTheme returns the theme browser sessions start in: the default theme,
+// or the high contrast one in accessible mode
+func (t *webTerminal) startTheme() *Theme {
+	theme := t.config.defaultTheme(t.config.DataLoader.Snapshot())
+	if t.config.Accessible {
+		themes := t.config.availableThemes()
+		if highContrast, ok := findTheme(themes, HighContrastThemeName); ok {
+			return highContrast.variantFor(themes, theme.Dark)
+		}
+	}
+	return theme
+}
+
+// webPalette returns p with every color as #rrggbb. CSS and xterm.js don't
+// know ANSI color numbers, so those become the RGB values terminals usually
+// show for them.
+func webPalette(p Palette) Palette {
+	fields := reflect.ValueOf(&p).Elem()
+	for i := range fields.NumField() {
+		color := termenv.TrueColor.Color(fields.Field(i).String())
+		fields.Field(i).SetString(termenv.ConvertToRGB(color).Hex())
+	}
+	return p
+}
+
+// webRenderer returns a renderer for the browser terminal. xterm.js supports
+// true color and the page background comes from the starting theme, so
+// nothing is detected.
+func webRenderer(theme *Theme) *lipgloss.Renderer {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.TrueColor)
+	renderer.SetHasDarkBackground(theme.Dark)
+	return renderer
+}
+
+func (t *webTerminal) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	id := randomID()
+	logger := log.With("session", id, "remote_addr", r.RemoteAddr)
+
+	if err := t.config.Limiter.Acquire(requestIP(r)); err != nil {
+		logger.Warn("Session rejected", "transport", transportWeb, "err", err)
+		status := http.StatusServiceUnavailable
+		if errors.Is(err, ErrRateLimited) {
+			status = http.StatusTooManyRequests
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	defer t.config.Limiter.Release()
+
+	conn, err := t.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied with an error
+		logger.Warn("WebSocket upgrade failed", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	width := queryInt(r, "cols", fallbackWidth, webMaxSize)
+	height := queryInt(r, "rows", fallbackHeight, webMaxSize)
+
+	ctx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+
+	input, inputWriter := io.Pipe()
+	defer inputWriter.Close()
+
+	model := NewPortfolioModelWithRenderer(width, height, t.config, webRenderer(t.startTheme()))
+	model.logger = logger
+	model.analytics = t.config.Analytics.StartSession(id, transportWeb, requestIP(r), "xterm.js", width, height)
+	defer model.analytics.End()
+
+	p := tea.NewProgram(model,
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+		tea.WithInput(input),
+		tea.WithOutput(&webSocketWriter{conn: conn}),
+		tea.WithContext(ctx),
+		tea.WithoutSignalHandler(),
+	)
+
+	t.config.Stats.SessionStarted()
+	defer t.config.Stats.SessionEnded()
+	defer t.config.Metrics.SessionStarted(transportWeb)()
+	t.config.Programs.Register(p)
+	defer t.config.Programs.Unregister(p)
+
+	start := time.Now()
+	logger.Info("Session started", "transport", transportWeb, "user_agent", r.UserAgent(), "width", width, "height", height)
+
+	go t.readInput(conn, p, inputWriter, cancel)
+	go keepAlive(ctx, conn)
+
+	// The output isn't a terminal, so the program can't query its size
+	go p.Send(tea.WindowSizeMsg{Width: width, Height: height})
+
+	if _, err := p.Run(); err != nil && ctx.Err() == nil {
+		logger.Error("Session failed", "err", err)
+	}
+	if t.ctx.Err() != nil {
+		model.analytics.SetEndReason(endShutdown)
+	}
+
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "session ended"),
+		time.Now().Add(time.Second))
+	logger.Info("Session ended", "duration", time.Since(start).Round(time.Millisecond))
+}
+
+// readInput forwards browser keystrokes to the program and applies resizes
+// until the connection closes
+func (t *webTerminal) readInput(conn *websocket.Conn, p *tea.Program, input *io.PipeWriter, cancel context.CancelFunc) {
+	defer cancel()
+
+	conn.SetReadLimit(webReadLimit)
+	_ = conn.SetReadDeadline(time.Now().Add(webPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(webPongTimeout))
+	})
+
+	for {
+		kind, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		switch kind {
+		case websocket.BinaryMessage:
+			if _, err := input.Write(data); err != nil {
+				return
+			}
+
+		case websocket.TextMessage:
+			var msg resizeMsg
+			if err := json.Unmarshal(data, &msg); err != nil || msg.Type != "resize" {
+				continue
+			}
+			if msg.Cols > 0 && msg.Rows > 0 {
+				p.Send(tea.WindowSizeMsg{
+					Width:  min(msg.Cols, webMaxSize),
+					Height: min(msg.Rows, webMaxSize),
+				})
+			}
+		}
+	}
+}
+
+// keepAlive pings the browser so dead connections are noticed
+func keepAlive(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(webPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// webSocketWriter sends program output to the browser as binary messages
+type webSocketWriter struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (w *webSocketWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.conn.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// requestIP returns the client's IP address, without the port
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// queryInt reads a positive integer query parameter, falling back to def
+func queryInt(r *http.Request, name string, def, limit int) int {
+	n, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || n <= 0 {
+		return def
+	}
+	return min(n, limit)
+}
+
+var webPageTemplate = template.Must(template.New("terminal").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} · Terminal Portfolio</title>
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/@xterm/xterm@5.5.0/css/xterm.min.css" crossorigin="anonymous">
+<style>
+  html, body { height: 100%; margin: 0; background: {{.Base}}; }
+  #terminal { position: absolute; inset: 0; padding: 8px; }
+  #status {
+    position: fixed; bottom: 12px; right: 16px; display: none;
+    font: 14px ui-monospace, monospace; color: {{.Base}}; background: {{.Yellow}};
+    padding: 4px 10px; border-radius: 6px; cursor: pointer;
+  }
+</style>
+</head>
+<body>
+<div id="terminal"></div>
+<div id="status">Session ended · click to reconnect</div>
+<script src="https://cdn.jsdelivr.net/npm/@xterm/xterm@5.5.0/lib/xterm.min.js" crossorigin="anonymous"></script>
+<script src="https://cdn.jsdelivr.net/npm/@xterm/addon-fit@0.10.0/lib/addon-fit.min.js" crossorigin="anonymous"></script>
+<script>
+  // The starting theme's palette, matching the terminal styles
+  const term = new Terminal({
+    cursorBlink: false,
+    fontFamily: "ui-monospace, SFMono-Regular, Menlo, Consolas, monospace",
+    fontSize: 15,
+    theme: {
+      background: "{{.Base}}", foreground: "{{.Text}}", cursor: "{{.Rosewater}}",
+      selectionBackground: "{{.Surface1}}",
+      black: "{{.Surface1}}", red: "{{.Red}}", green: "{{.Green}}", yellow: "{{.Yellow}}",
+      blue: "{{.Blue}}", magenta: "{{.Pink}}", cyan: "{{.Teal}}", white: "{{.Subtext1}}",
+      brightBlack: "{{.Overlay1}}", brightRed: "{{.Red}}", brightGreen: "{{.Green}}", brightYellow: "{{.Yellow}}",
+      brightBlue: "{{.Blue}}", brightMagenta: "{{.Pink}}", brightCyan: "{{.Teal}}", brightWhite: "{{.Subtext0}}",
+    },
+  });
+  const fit = new FitAddon.FitAddon();
+  term.loadAddon(fit);
+  term.open(document.getElementById("terminal"));
+  fit.fit();
+
+  const status = document.getElementById("status");
+  const encoder = new TextEncoder();
+  let socket;
+
+  function connect() {
+    status.style.display = "none";
+    term.reset();
+    const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+    socket = new WebSocket(scheme + "//" + location.host + "/ws?cols=" + term.cols + "&rows=" + term.rows);
+    socket.binaryType = "arraybuffer";
+    socket.onmessage = (event) => term.write(new Uint8Array(event.data));
+    socket.onclose = () => { status.style.display = "block"; };
+    term.focus();
+  }
+
+  term.onData((data) => {
+    if (socket.readyState === WebSocket.OPEN) socket.send(encoder.encode(data));
+  });
+  term.onResize(({ cols, rows }) => {
+    if (socket.readyState === WebSocket.OPEN) socket.send(JSON.stringify({ type: "resize", cols, rows }));
+  });
+  window.addEventListener("resize", () => fit.fit());
+  status.addEventListener("click", connect);
+
+  connect();
+</script>
+</body>
+</html>
+`))
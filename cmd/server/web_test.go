@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWebPalette(t *testing.T) {
+	palette := builtinThemes[0].Palette
+	palette.Base = "4"
+	palette.Text = "231"
+	palette.Blue = "#abc"
+	palette.Red = "#FF0000"
+
+	got := webPalette(palette)
+	for _, tt := range []struct {
+		name  string
+		color lipgloss.Color
+		want  string
+	}{
+		{"ANSI", got.Base, "#000080"},
+		{"ANSI 256", got.Text, "#ffffff"},
+		{"short hex", got.Blue, "#aabbcc"},
+		{"upper case hex", got.Red, "#ff0000"},
+	} {
+		if string(tt.color) != tt.want {
+			t.Errorf("%s color became %q, want %q", tt.name, tt.color, tt.want)
+		}
+	}
+}
+
+func TestWebPageColors(t *testing.T) {
+	theme := &Theme{Name: "ansi", Dark: true, Palette: builtinThemes[0].Palette}
+	theme.Palette.Base = "0"
+	theme.Palette.Yellow = "11"
+
+	config := &ServerConfig{
+		DataLoader: newTestLoader(t),
+		Programs:   NewProgramRegistry(),
+		Theme:      "ansi",
+		Themes:     AvailableThemes([]*Theme{theme}),
+	}
+	rec := httptest.NewRecorder()
+	(&webTerminal{config: config}).servePage(rec, httptest.NewRequest("GET", "/", nil))
+	page := rec.Body.String()
+
+	if !strings.Contains(page, "background: #000000;") || !strings.Contains(page, `background: "#000000"`) {
+		t.Error("the ANSI base color wasn't converted for CSS and xterm.js")
+	}
+	for _, m := range regexp.MustCompile(`(?:background|color|black|yellow|foreground): "?([^";]*)`).FindAllStringSubmatch(page, -1) {
+		if !regexp.MustCompile(`^#[0-9a-f]{6}$`).MatchString(m[1]) {
+			t.Errorf("color %q in the page isn't #rrggbb", m[1])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// serveIndex sends terminal clients like curl every section as text, and
+// browsers the web terminal
+func (t *webTerminal) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if !isTerminalClient(r.UserAgent()) {
+		t.servePage(w, r)
+		return
+	}
+
+	t.serveText(w, r, allTextSections(t.config))
+}
+
+// serveSection sends a single section as text
+func (t *webTerminal) serveSection(w http.ResponseWriter, r *http.Request) {
+	if section, ok := lookupTextSection(t.config, r.PathValue("section")); ok {
+		t.serveText(w, r, []Section{section})
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// serveText renders sections with the same renderers as the TUI. Output is
+// colored for terminal clients unless ?color=0 is set; ?width=N sets the
+// wrapping width and ?a11y=1 turns on accessible mode.
+func (t *webTerminal) serveText(w http.ResponseWriter, r *http.Request, sections []Section) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(textColorProfile(r))
+
+	hint := "Explore the interactive version: ssh " + requestHost(r)
+	if t.config.Port != 22 {
+		hint += " -p " + strconv.FormatUint(uint64(t.config.Port), 10)
+	}
+
+	width := queryInt(r, "width", textDefaultWidth, webMaxSize)
+	accessible := t.config.Accessible
+	if on, err := strconv.ParseBool(r.URL.Query().Get("a11y")); err == nil {
+		accessible = on
+	}
+	text := renderText(t.config, sections, width, renderer, accessible, hint)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Vary", "User-Agent")
+	_, _ = io.WriteString(w, text)
+}
+
+// isTerminalClient reports whether a user agent belongs to a command line
+// HTTP client rather than a browser
+func isTerminalClient(userAgent string) bool {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return true
+	}
+	for _, client := range []string{"curl/", "wget/", "httpie/", "xh/", "powershell/", "libfetch/", "fetch libfetch"} {
+		if strings.HasPrefix(ua, client) || strings.Contains(ua, " "+client) {
+			return true
+		}
+	}
+	return false
+}
+
+// textColorProfile picks the color profile from ?color: 0/false/no/off for
+// none, 16 or 256 for limited palettes, anything else for true color.
+// Browsers get uncolored text by default since they don't render ANSI.
+func textColorProfile(r *http.Request) termenv.Profile {
+	value, set := r.URL.Query()["color"]
+	if !set {
+		if isTerminalClient(r.UserAgent()) {
+			return termenv.TrueColor
+		}
+		return termenv.Ascii
+	}
+
+	switch strings.ToLower(value[0]) {
+	case "0", "false", "no", "off":
+		return termenv.Ascii
+	case "16":
+		return termenv.ANSI
+	case "256":
+		return termenv.ANSI256
+	default:
+		return termenv.TrueColor
+	}
+}
+
+// requestHost returns the host name the client used, without the port
+func requestHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		return r.Host
+	}
+	return host
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"tui-portfolio/cmd/server"
+)
+
+// runStats summarizes an analytics log written with -analytics and returns
+// the exit code
+func runStats(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Usage: %s stats [options] [file]
+
+Summarizes a visitor analytics log recorded with -analytics: visitors per
+day, average session length and the most viewed sections. The file defaults
+to %s.
+
+Options:
+`, os.Args[0], defaultAnalyticsPath)
+		fs.PrintDefaults()
+	}
+
+	days := fs.Int("days", 0, "Only include the last N days (default: the whole log)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	path := defaultAnalyticsPath
+	if fs.NArg() == 1 {
+		path = fs.Arg(0)
+	}
+
+	var since time.Time
+	if *days > 0 {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		since = today.AddDate(0, 0, 1-*days)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer file.Close()
+
+	summary, err := server.SummarizeAnalytics(file, since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return 1
+	}
+
+	printStats(summary)
+	if summary.SkippedLines > 0 {
+		fmt.Fprintf(os.Stderr, "%s: skipped %d malformed line(s)\n", path, summary.SkippedLines)
+	}
+	return 0
+}
+
+// printStats writes a summary as aligned text tables
+func printStats(s *server.AnalyticsSummary) {
+	if s.Sessions == 0 {
+		fmt.Println("No sessions recorded.")
+		return
+	}
+
+	fmt.Printf("%d session(s) from %d visitor(s), %s to %s\n", s.Sessions, s.Visitors,
+		s.First.UTC().Format(time.DateOnly), s.Last.UTC().Format(time.DateOnly))
+	fmt.Printf("Average session: %s\n", roundDuration(s.AverageDuration()))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	fmt.Println("\nVisitors per day:")
+	fmt.Fprintln(w, "Day\tVisitors\tSessions")
+	for _, day := range s.Days {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", day.Day, day.Visitors, day.Sessions)
+	}
+	w.Flush()
+
+	if len(s.Sections) > 0 {
+		fmt.Println("\nMost viewed sections:")
+		fmt.Fprintln(w, "Section\tViews\tAvg time\tAvg scroll")
+		for _, section := range s.Sections {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d%%\n", section.Section, section.Views,
+				roundDuration(section.AverageTime()), section.AverageScroll())
+		}
+		w.Flush()
+	}
+
+	if len(s.EndReasons) > 0 {
+		reasons := make([]string, 0, len(s.EndReasons))
+		for reason := range s.EndReasons {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			return s.EndReasons[reasons[i]] > s.EndReasons[reasons[j]] ||
+				s.EndReasons[reasons[i]] == s.EndReasons[reasons[j]] && reasons[i] < reasons[j]
+		})
+
+		counts := make([]string, len(reasons))
+		for i, reason := range reasons {
+			counts[i] = fmt.Sprintf("%s %d", reason, s.EndReasons[reason])
+		}
+		fmt.Printf("\nSessions ended by: %s\n", strings.Join(counts, ", "))
+	}
+}
+
+// roundDuration rounds a duration to whole seconds for display
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Second)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"tui-portfolio/cmd/server"
+)
+
+// runValidate loads one or more data files, prints every problem found and
+// returns the exit code: 0 when valid, 1 when any file has errors (or
+// warnings with -strict), 2 for bad usage.
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `Usage: %s validate [options] [path ...]
+
+Checks portfolio data files without starting the server. Paths can be given
+as arguments (useful in pre-commit hooks) or with -data. With -themes, the
+theme files are checked too, and data files may pick those themes.
+
+Options:
+`, os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	var (
+		dataPath = fs.String("data", defaultDataPath, "Path to portfolio data file (JSON, YAML or TOML) or Markdown directory")
+		format   = fs.String("format", "", "Data file format: json, yaml, toml or markdown (default: detected from path)")
+		themes   = fs.String("themes", "", "Directory of theme files to check and make available")
+		strict   = fs.Bool("strict", false, "Treat warnings as errors")
+		quiet    = fs.Bool("quiet", false, "Only print problems, not the per-file summary")
+	)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	dataFormat, err := server.ParseDataFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid -format:", err)
+		return 2
+	}
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{*dataPath}
+	}
+
+	exitCode := 0
+	userThemes, err := server.LoadThemes(*themes)
+	if err != nil {
+		fmt.Println(err)
+		exitCode = 1
+	}
+	available := server.AvailableThemes(userThemes)
+
+	for _, path := range paths {
+		if !validateDataFile(path, dataFormat, available, *strict, *quiet) {
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
+
+// validateDataFile prints the problems in a single data file, which may pick
+// any of themes, and reports whether it passed
+func validateDataFile(path string, format server.DataFormat, themes []*server.Theme, strict, quiet bool) bool {
+	dataLoader := server.NewDataLoader(path, format)
+	if err := dataLoader.LoadData(); err != nil {
+		fmt.Printf("%s: error: %v\n", path, err)
+		return false
+	}
+
+	var errors, warnings int
+	for _, issue := range dataLoader.Validate(themes) {
+		fmt.Printf("%s: %s: %s\n", path, issue.Severity, issue)
+		if issue.Severity == server.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("%s: %d error(s), %d warning(s)\n", path, errors, warnings)
+	}
+	return errors == 0 && (!strict || warnings == 0)
+}